@@ -0,0 +1,201 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nlfiedler/sortingo/internal/generate"
+)
+
+// Config describes a benchmark run: the algorithms, the data sets and
+// their sizes, and how the sorts are measured. Each profile provides a
+// complete Config, of which a config file may replace any of the fields
+// except the size presets. For example:
+//
+//	{
+//	    "profile": "micro",
+//	    "algorithms": ["Intro", "Shell", "2PivotQ"],
+//	    "datasets": ["Random", "Adversary2PivotQ"],
+//	    "sizes": [10, 100, 1000],
+//	    "mintime": "20ms"
+//	}
+type Config struct {
+	Profile    string           `json:"profile"`
+	Algorithms []string         `json:"algorithms"`
+	DataSets   []string         `json:"datasets"`
+	Sizes      []int            `json:"sizes"`
+	Files      []string         `json:"files"`
+	Sample     bool             `json:"sample"`
+	Samples    int              `json:"samples"`
+	Warmup     int              `json:"warmup"`
+	MinTime    Duration         `json:"mintime"`
+	Seed       int64            `json:"seed"`
+	Presets    map[string][]int `json:"-"`
+}
+
+// Duration is a time.Duration written in config files as a string,
+// such as "100ms".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"100ms\"")
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// defaultProfile is the profile used if none is named.
+const defaultProfile = "large"
+
+// profileNames lists the profiles in the order they are displayed.
+var profileNames = []string{"large", "micro"}
+
+// profiles maps the profile names to their settings. The large profile
+// measures the fastest algorithms on millions of strings, while the
+// micro profile measures all of the others on small inputs.
+var profiles = map[string]Config{
+	"large": {
+		Algorithms: []string{"Merge", "Burst"},
+		DataSets:   append(slices.Clone(generate.Names), "Adversary"),
+		Samples:    5,
+		Warmup:     1,
+		MinTime:    Duration(100 * time.Millisecond),
+		Presets: map[string][]int{
+			"small":  {10000, 33000, 100000},
+			"medium": {330000, 1000000, 3000000},
+			"large":  {3000000, 10000000, 30000000},
+		},
+	},
+	"micro": {
+		Algorithms: []string{"Binsert", "Comb", "2PivotQ", "Gnome", "Heap", "HybridComb",
+			"Insert", "Intro", "MkQ", "Quick", "Select", "Shell"},
+		DataSets: append(slices.Clone(generate.Names), "Adversary2PivotQ"),
+		Samples:  10,
+		Warmup:   1,
+		MinTime:  Duration(50 * time.Millisecond),
+		Presets: map[string][]int{
+			"small":  {12, 20, 52},
+			"medium": {12, 20, 52, 100, 400},
+			"large":  {100, 400, 1000, 4000},
+		},
+	},
+}
+
+// profileConfig returns a copy of the settings of the named profile,
+// using the medium size preset.
+func profileConfig(name string) (Config, error) {
+	p, ok := profiles[name]
+	if !ok {
+		return Config{}, fmt.Errorf("unknown profile '%s', expected one of %s",
+			name, strings.Join(profileNames, ", "))
+	}
+	// copy the lists so that decoding a config file cannot alter them
+	p.Profile = name
+	p.Algorithms = slices.Clone(p.Algorithms)
+	p.DataSets = slices.Clone(p.DataSets)
+	p.Sizes = slices.Clone(p.Presets["medium"])
+	return p, nil
+}
+
+// loadConfig reads the config file, applying its settings to those of
+// a profile. The profile is the one given, if not empty, or else the
+// one named in the file, or else the default profile.
+func loadConfig(path, profile string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	var named struct {
+		Profile string `json:"profile"`
+	}
+	if err := json.Unmarshal(data, &named); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", path, err)
+	}
+	if profile == "" {
+		profile = named.Profile
+	}
+	if profile == "" {
+		profile = defaultProfile
+	}
+	cfg, err := profileConfig(profile)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %v", path, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", path, err)
+	}
+	cfg.Profile = profile
+	return cfg, nil
+}
+
+// validate checks that the settings are usable, and that every named
+// algorithm and data set exists.
+func (cfg *Config) validate() error {
+	for _, name := range cfg.Algorithms {
+		if _, ok := sorters[name]; !ok {
+			return fmt.Errorf("unknown algorithm '%s'", name)
+		}
+	}
+	// data sets are ignored when sorting the records of files
+	if len(cfg.Files) == 0 {
+		for _, name := range cfg.DataSets {
+			if _, ok := dataGenerators[name]; !ok {
+				return fmt.Errorf("unknown data set '%s'", name)
+			}
+		}
+	}
+	for _, size := range cfg.Sizes {
+		if size < 1 {
+			return fmt.Errorf("invalid size %d, expected a positive count", size)
+		}
+	}
+	if cfg.Samples < 1 {
+		return fmt.Errorf("number of samples must be positive")
+	}
+	if cfg.Warmup < 0 {
+		return fmt.Errorf("number of warm-up sorts must not be negative")
+	}
+	return nil
+}
+
+// parseSizes interprets the value of the --size flag, which is either
+// the name of one of the presets or a comma-separated list of positive
+// counts.
+func parseSizes(value string, presets map[string][]int) ([]int, error) {
+	if sizes, ok := presets[strings.ToLower(value)]; ok {
+		return sizes, nil
+	}
+	var sizes []int
+	for _, field := range strings.Split(value, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || size < 1 {
+			return nil, fmt.Errorf("invalid size '%s', expected small, medium, large, or positive counts", field)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
@@ -0,0 +1,135 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// writeConfig writes the contents to a config file in a new directory.
+func writeConfig(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "bench.json")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestProfiles(t *testing.T) {
+	for _, name := range profileNames {
+		cfg, err := profileConfig(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cfg.validate(); err != nil {
+			t.Errorf("profile %s is invalid: %v", name, err)
+		}
+		if !reflect.DeepEqual(cfg.Sizes, cfg.Presets["medium"]) {
+			t.Errorf("profile %s should default to the medium sizes", name)
+		}
+	}
+	if _, err := profileConfig("huge"); err == nil {
+		t.Error("unknown profile should be an error")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, `{
+		"profile": "micro",
+		"algorithms": ["Intro"],
+		"sizes": [10, 100],
+		"warmup": 0,
+		"mintime": "20ms",
+		"seed": 99
+	}`)
+	cfg, err := loadConfig(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Profile != "micro" || !reflect.DeepEqual(cfg.Algorithms, []string{"Intro"}) {
+		t.Errorf("unexpected profile %s or algorithms %v", cfg.Profile, cfg.Algorithms)
+	}
+	if !reflect.DeepEqual(cfg.Sizes, []int{10, 100}) || cfg.Warmup != 0 || time.Duration(cfg.MinTime) != 20*time.Millisecond || cfg.Seed != 99 {
+		t.Errorf("settings not applied: %+v", cfg)
+	}
+	// settings absent from the file come from the profile
+	if cfg.Samples != profiles["micro"].Samples || len(cfg.DataSets) != len(profiles["micro"].DataSets) {
+		t.Errorf("profile settings not retained: %+v", cfg)
+	}
+	// the profile given on the command line takes precedence
+	cfg, err = loadConfig(path, "large")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Profile != "large" || cfg.Samples != profiles["large"].Samples {
+		t.Errorf("expected large profile, got %+v", cfg)
+	}
+	// decoding must not alter the profiles themselves
+	if profiles["micro"].Algorithms[0] != "Binsert" {
+		t.Error("config file altered the profile")
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	for _, contents := range []string{
+		`{"profile": "huge"}`,
+		`{"algorithm": ["Intro"]}`,
+		`{"mintime": 100}`,
+		`{"mintime": "soon"}`,
+		`{"sizes": [10,`,
+	} {
+		if _, err := loadConfig(writeConfig(t, contents), ""); err == nil {
+			t.Errorf("expected error loading %s", contents)
+		}
+	}
+	if _, err := loadConfig(filepath.Join(t.TempDir(), "missing.json"), ""); err == nil {
+		t.Error("expected error loading missing file")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []func(*Config){
+		func(c *Config) { c.Algorithms = []string{"Bogo"} },
+		func(c *Config) { c.DataSets = []string{"Noise"} },
+		func(c *Config) { c.Sizes = []int{10, 0} },
+		func(c *Config) { c.Samples = 0 },
+		func(c *Config) { c.Warmup = -1 },
+	}
+	for i, modify := range tests {
+		cfg, _ := profileConfig("micro")
+		modify(&cfg)
+		if err := cfg.validate(); err == nil {
+			t.Errorf("case %d: expected invalid config", i)
+		}
+	}
+	// data sets do not matter when sorting files
+	cfg, _ := profileConfig("micro")
+	cfg.DataSets = []string{"Noise"}
+	cfg.Files = []string{"words.txt"}
+	if err := cfg.validate(); err != nil {
+		t.Errorf("data sets should be ignored with files: %v", err)
+	}
+}
+
+func TestParseSizes(t *testing.T) {
+	presets := profiles["micro"].Presets
+	if sizes, err := parseSizes("Small", presets); err != nil || !reflect.DeepEqual(sizes, presets["small"]) {
+		t.Errorf("unexpected sizes %v (%v) for preset", sizes, err)
+	}
+	if sizes, err := parseSizes("5, 50", presets); err != nil || !reflect.DeepEqual(sizes, []int{5, 50}) {
+		t.Errorf("unexpected sizes %v (%v) for list", sizes, err)
+	}
+	for _, value := range []string{"tiny", "5,-1", "5,,6"} {
+		if _, err := parseSizes(value, presets); err == nil {
+			t.Errorf("expected error parsing %q", value)
+		}
+	}
+}
@@ -7,182 +7,275 @@
 package main
 
 import (
-	"bytes"
-	"github.com/nlfiedler/sortingo/sort"
 	"flag"
 	"fmt"
 	"math/rand"
 	"os"
 	"regexp"
+	"slices"
+	gosort "sort"
 	"strings"
 	"time"
-)
 
-// runCount is the number of times each sort is measured to compute an average.
-const runCount = 5
+	"github.com/nlfiedler/sortingo/internal/bench"
+	"github.com/nlfiedler/sortingo/internal/dataset"
+	"github.com/nlfiedler/sortingo/internal/generate"
+	"github.com/nlfiedler/sortingo/internal/report"
+	"github.com/nlfiedler/sortingo/sort"
+)
 
-// sorterNames are the name of the sort algorithms in the desired run order.
-var sorterNames = []string{"Merge", "Burst"}
+// sorterNames are the names of all the sort algorithms, in the order
+// in which they are listed.
+var sorterNames = []string{"Binsert", "Burst", "Comb", "2PivotQ", "Gnome", "Heap", "HybridComb",
+	"Insert", "Intro", "Merge", "MkQ", "Quick", "Select", "Shell"}
 
 // sorters maps sort algorithm names to implementing functions.
 var sorters = make(map[string]func([]string))
 
-// sortSizes are the different sizes of data used in testing, in the desired run order.
-var sortSizes = []int{330000, 1000000, 3000000}
-
-// dataSetNames are the names of the data sets, in the desired run order.
-var dataSetNames = []string{"Repeat", "RepeatCycle", "Random", "PseudoWords", "SmallAlphabet", "Genome"}
+// dataSetNames are the names of all the generated data sets, in the
+// order in which they are listed.
+var dataSetNames = append(slices.Clone(generate.Names), "Adversary", "Adversary2PivotQ")
 
 // dataGenerators maps data set names to data generator functions.
-var dataGenerators = make(map[string]func(size int) []string)
+var dataGenerators = make(map[string]generate.Generator)
 
 // init sets up the benchmark data structures.
 func init() {
-	sorters["Merge"] = sort.MergeSort
+	sorters["Binsert"] = sort.BinaryInsertionSort
 	sorters["Burst"] = sort.BurstSort
+	sorters["Comb"] = sort.CombSort
+	sorters["2PivotQ"] = sort.DualPivotQuickSort
+	sorters["Gnome"] = sort.GnomeSort
+	sorters["Heap"] = sort.HeapSort
+	sorters["HybridComb"] = sort.HybridCombSort
+	sorters["Insert"] = sort.InsertionSort
+	sorters["Intro"] = sort.IntroSort
+	sorters["Merge"] = sort.MergeSort
+	sorters["MkQ"] = sort.MultikeyQuickSort
+	sorters["Quick"] = gosort.Strings
+	sorters["Select"] = sort.SelectionSort
+	sorters["Shell"] = sort.ShellSort
 
-	dataGenerators["Repeat"] = generateRepeated
-	dataGenerators["RepeatCycle"] = generateRepeatedCycle
-	dataGenerators["Random"] = generateRandom
-	dataGenerators["PseudoWords"] = generateUniqueWords
-	dataGenerators["SmallAlphabet"] = generateSmallAlpha
-	dataGenerators["Genome"] = generateGenome
+	for name, gen := range generate.Generators {
+		dataGenerators[name] = gen
+	}
+	dataGenerators["Adversary"] = generateAdversary
+	dataGenerators["Adversary2PivotQ"] = generateAdversary2PivotQ
 }
 
-// generateRepeated generates the repeated strings test data.
-func generateRepeated(size int) []string {
-	repeatedStrings := make([]string, size)
-	a100 := strings.Repeat("A", 100)
-	for idx := range repeatedStrings {
-		repeatedStrings[idx] = a100
-	}
-	return repeatedStrings
+// generateAdversary generates a worst-case input for the introsort,
+// consisting of numeric strings. The adversary is run against IntroSort
+// rather than one of the quicksorts, whose quadratic behavior would
+// make generating large data sets impractical.
+func generateAdversary(r *rand.Rand, size int) []string {
+	return sort.AdversaryStrings(size, sort.IntroSortFunc[int])
 }
 
-// generateRepeatedCycle generates a repeating cycle of strings.
-func generateRepeatedCycle(size int) []string {
-	a100 := strings.Repeat("A", 100)
-	strs := make([]string, len(a100))
-	for i := range strs {
-		strs[i] = a100[0 : i+1]
-	}
-	repeatedCycleStrings := make([]string, size)
-	c := 0
-	for i := range repeatedCycleStrings {
-		repeatedCycleStrings[i] = strs[c]
-		if c++; c >= len(strs) {
-			c = 0
-		}
-	}
-	return repeatedCycleStrings
+// generateAdversary2PivotQ generates a worst-case input for the dual
+// pivot quicksort, which has no protection against such input; the
+// time needed grows with the square of the size.
+func generateAdversary2PivotQ(r *rand.Rand, size int) []string {
+	return sort.AdversaryStrings(size, sort.DualPivotQuickSortFunc[int])
 }
 
-// generateRandom generates a set of random strings, each of length 100.
-func generateRandom(size int) []string {
-	randomStrings := make([]string, size)
-	for i := range randomStrings {
-		bb := bytes.NewBuffer(make([]byte, 0, 100))
-		for j := 0; j < 100; j++ {
-			d := rand.Int31n(95)
-			bb.WriteRune(' ' + d)
-		}
-		randomStrings[i] = string(bb.Bytes())
+// chooseSeed returns the seed for generating the data, as does
+// generate.ChooseSeed, except that when comparing with a baseline, its
+// seed is used in place of one chosen from the time, so that the same
+// data is sorted.
+func chooseSeed(seed int64, base *report.Report) (int64, error) {
+	if seed == 0 && base != nil && os.Getenv(generate.SeedEnv) == "" {
+		seed = base.Metadata.Seed
 	}
-	return randomStrings
+	return generate.ChooseSeed(seed)
 }
 
-// generateUniqueWords generates a set of unique pseudo words.
-func generateUniqueWords(size int) []string {
-	uniqueWords := make([]string, size)
-	wordExists := make(map[string]bool)
-	for i := range uniqueWords {
-		var s string
-		// Loop until a unique random word is generated.
-		for {
-			// Each word is from 1 to 28 characters long.
-			l := 1 + rand.Intn(27)
-			bb := bytes.NewBuffer(make([]byte, 0, l))
-			// Each word consists only of the lowercase letters.
-			for j := 0; j < l; j++ {
-				d := rand.Int31n(26)
-				bb.WriteRune('a' + d)
-			}
-			s = string(bb.Bytes())
-			if !wordExists[s] {
-				break
-			}
-		}
-		uniqueWords[i] = s
-		wordExists[s] = true
+// formatTime formats the nanoseconds in a unit suited to their size.
+func formatTime(ns float64) string {
+	switch {
+	case ns >= 1e9:
+		return fmt.Sprintf("%.3fs", ns/1e9)
+	case ns >= 1e6:
+		return fmt.Sprintf("%.3fms", ns/1e6)
+	case ns >= 1e3:
+		return fmt.Sprintf("%.3fµs", ns/1e3)
 	}
-	return uniqueWords
+	return fmt.Sprintf("%.1fns", ns)
 }
 
-// generateSmallAlpha generates a set of random strings, each of length
-// 100, consisting of a small alphabet of characters.
-func generateSmallAlpha(size int) []string {
-	smallAlphaStrings := make([]string, size)
-	for i := range smallAlphaStrings {
-		l := 1 + rand.Intn(100)
-		bb := bytes.NewBuffer(make([]byte, 0, l))
-		for j := 0; j < l; j++ {
-			d := rand.Int31n(9)
-			bb.WriteRune('a' + d)
-		}
-		smallAlphaStrings[i] = string(bb.Bytes())
+// memoryNote describes the memory used by the sort of the result.
+func memoryNote(result report.Result) string {
+	return fmt.Sprintf("%s peak, %s in %d allocs", report.FormatBytes(result.PeakHeap),
+		report.FormatBytes(result.Bytes), result.Allocs)
+}
+
+// outlierNote describes the outliers among the samples of the result,
+// if there were any.
+func outlierNote(result report.Result) string {
+	if result.Outliers == 0 {
+		return ""
+	}
+	if result.Outliers == 1 {
+		return " (1 outlier)"
 	}
-	return smallAlphaStrings
+	return fmt.Sprintf(" (%d outliers)", result.Outliers)
 }
 
-// Generate a set of random "genome" strings, each of length 9,
-// consisting of the letters a, c, g, t.
-func generateGenome(size int) []string {
-	genomeStrings := make([]string, size)
-	for i := range genomeStrings {
-		bb := bytes.NewBuffer(make([]byte, 0, 9))
-		for j := 0; j < 9; j++ {
-			d := rand.Intn(4)
-			switch d {
-			case 0:
-				bb.WriteRune('a')
-			case 1:
-				bb.WriteRune('c')
-			case 2:
-				bb.WriteRune('g')
-			case 3:
-				bb.WriteRune('t')
-			}
+// fileList holds the values of the repeatable --file flag.
+type fileList []string
+
+func (f *fileList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// filterNames returns the names that match the regular expression,
+// ignoring case.
+func filterNames(names []string, pattern string) ([]string, error) {
+	re, err := regexp.Compile(strings.ToLower(pattern))
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]string, 0, len(names))
+	for _, name := range names {
+		if idx := re.FindStringIndex(strings.ToLower(name)); idx != nil {
+			matched = append(matched, name)
 		}
-		genomeStrings[i] = string(bb.Bytes())
 	}
-	return genomeStrings
+	return matched, nil
 }
 
 // usage displays command line usage information.
 func usage() {
 	fmt.Println("Usage: sortbench [options]")
+	fmt.Println("\t--alpha <level>")
+	fmt.Println("\t\tSignificance level of the Mann-Whitney test used by --baseline;")
+	fmt.Println("\t\tthe default is 0.05.")
+	fmt.Println("\t--baseline <path>")
+	fmt.Println("\t\tCompare the results with those saved by --save, showing the")
+	fmt.Println("\t\tchange in the median time of each algorithm, data set, and size.")
+	fmt.Println("\t\tExits with status 2 if any are significantly slower than the")
+	fmt.Println("\t\tbaseline by more than the --threshold percentage. Unless a seed")
+	fmt.Println("\t\tis given, the seed of the baseline is used to generate the data.")
+	fmt.Println("\t--config <path>")
+	fmt.Println("\t\tRead the settings from a JSON file, which may name the profile")
+	fmt.Println("\t\tand replace any of its settings, which are: algorithms, datasets,")
+	fmt.Println("\t\tsizes, files, sample, samples, warmup, mintime, and seed. Flags")
+	fmt.Println("\t\tgiven on the command line take precedence over the file.")
+	fmt.Println("\t--cpuprofile <dir>")
+	fmt.Println("\t\tWrite a CPU profile of each algorithm, data set, and size to the")
+	fmt.Println("\t\tdirectory, named like Intro-Random-1000.cpu.pprof.")
 	fmt.Println("\t--data <regex>")
-	fmt.Println("\t\tSelect the data set whose name matches the regular expression.")
-	fmt.Println("\t\tFor example, '--data random' would use only the random data set.")
+	fmt.Println("\t\tSelect the data sets of the profile whose names match the regular")
+	fmt.Println("\t\texpression. For example, '--data random' would use only the")
+	fmt.Println("\t\trandom data set.")
+	fmt.Println("\t--file <path>")
+	fmt.Println("\t\tSort the records of the file instead of the generated data sets;")
+	fmt.Println("\t\tmay be given more than once. Records are separated by newlines,")
+	fmt.Println("\t\tor by nulls if the file contains any, and the file may be")
+	fmt.Println("\t\tcompressed with gzip. Each file is truncated to the data size.")
+	fmt.Println("\t--format <text|json|csv>")
+	fmt.Println("\t\tSelect the output format. The text format (the default) shows")
+	fmt.Println("\t\tthe results as they are measured, while json and csv write all")
+	fmt.Println("\t\tof the results at the end, with statistics and system details.")
 	fmt.Println("\t--help")
 	fmt.Println("\t\tDisplay this usage information.")
 	fmt.Println("\t--list")
-	fmt.Println("\t\tDisplay a list of the supported data sets and sorting algorithms.")
+	fmt.Println("\t\tDisplay a list of the profiles, data sets, and sorting algorithms.")
+	fmt.Println("\t--memprofile <dir>")
+	fmt.Println("\t\tWrite a heap profile of each algorithm, data set, and size to")
+	fmt.Println("\t\tthe directory. The allocations in each profile include those of")
+	fmt.Println("\t\tthe earlier profiles; use the previous profile as the -diff_base")
+	fmt.Println("\t\tof 'go tool pprof' to see only those of a single sort.")
+	fmt.Println("\t--mintime <duration>")
+	fmt.Println("\t\tMinimum duration of each sample; sorts that take less time are")
+	fmt.Println("\t\trepeated within a sample as often as needed.")
+	fmt.Println("\t--profile <large|micro>")
+	fmt.Println("\t\tSelect the profile, which determines the algorithms, data sets,")
+	fmt.Println("\t\tsizes, and measurement settings. The large profile (the default)")
+	fmt.Println("\t\tmeasures the fastest algorithms on millions of strings, while the")
+	fmt.Println("\t\tmicro profile measures the others on small inputs.")
+	fmt.Println("\t--sample")
+	fmt.Println("\t\tRandomly sample the records of each file, retaining their order,")
+	fmt.Println("\t\trather than taking the first records for each data size.")
+	fmt.Println("\t--samples <count>")
+	fmt.Println("\t\tNumber of samples to measure for each sort, from which the")
+	fmt.Println("\t\tmean, confidence interval, and outliers are found.")
+	fmt.Println("\t--save <path>")
+	fmt.Println("\t\tSave the results to the file in the json format, for later use")
+	fmt.Println("\t\twith --baseline.")
+	fmt.Println("\t--seed <number>")
+	fmt.Println("\t\tSeed for generating the data sets and sampling files, which")
+	fmt.Println("\t\tmay also be given by the SORTINGO_SEED environment variable;")
+	fmt.Println("\t\totherwise the seed of the --baseline results is used, if any,")
+	fmt.Println("\t\tor else a seed is chosen from the time. The seed is shown")
+	fmt.Println("\t\tin the output so that the same data can be sorted again.")
+	fmt.Println("\t--size <sizes>")
+	fmt.Println("\t\tSelect the data sizes, either one of the presets small, medium")
+	fmt.Println("\t\t(the default), or large of the profile, or a comma-separated")
+	fmt.Println("\t\tlist of counts.")
 	fmt.Println("\t--sort <regex>")
-	fmt.Println("\t\tSelect the sort algorithms whose name matches the regular")
-	fmt.Println("\t\texpression. For example, '--sort (comb|insert)' would run")
-	fmt.Println("\t\tboth versions of the insertion and comb sort algorithms.")
+	fmt.Println("\t\tSelect the sort algorithms of the profile whose names match the")
+	fmt.Println("\t\tregular expression. For example, '--profile micro --sort")
+	fmt.Println("\t\t(comb|insert)' would run both versions of the insertion and comb")
+	fmt.Println("\t\tsort algorithms.")
+	fmt.Println("\t--threshold <percent>")
+	fmt.Println("\t\tPercentage by which an algorithm may be slower than the baseline")
+	fmt.Println("\t\tbefore it is regarded as a regression; the default is 10.")
+	fmt.Println("\t--warmup <count>")
+	fmt.Println("\t\tNumber of unmeasured sorts run before measuring.")
+}
+
+// list displays the profiles, data sets, and sorting algorithms.
+func list() {
+	fmt.Println("Profiles")
+	for _, name := range profileNames {
+		p := profiles[name]
+		fmt.Printf("\t%s\n", name)
+		fmt.Printf("\t\talgorithms: %s\n", strings.Join(p.Algorithms, ", "))
+		fmt.Printf("\t\tdata sets:  %s\n", strings.Join(p.DataSets, ", "))
+		for _, preset := range []string{"small", "medium", "large"} {
+			fmt.Printf("\t\t%-8s    %v\n", preset+":", p.Presets[preset])
+		}
+		fmt.Printf("\t\tsamples: %d, warmup: %d, mintime: %v\n", p.Samples, p.Warmup,
+			time.Duration(p.MinTime))
+	}
+	fmt.Println("Data sets")
+	for _, dataSetName := range dataSetNames {
+		fmt.Printf("\t%s\n", dataSetName)
+	}
+	fmt.Println("Sorting algorithms")
+	for _, sorterName := range sorterNames {
+		fmt.Printf("\t%s\n", sorterName)
+	}
 }
 
-// main runs the benchmarks on the "faster" sorting algorithms using
-// large data sets.
+// main runs the benchmarks of the chosen profile, or config file.
 func main() {
 	var help = flag.Bool("help", false, "show usage information")
-	var list = flag.Bool("list", false, "list supported data sets and algorithms")
+	var listFlag = flag.Bool("list", false, "list profiles, data sets, and algorithms")
+	var profile = flag.String("profile", "", "benchmark profile: large or micro")
+	var configPath = flag.String("config", "", "JSON file of benchmark settings")
 	var data = flag.String("data", "", "regex to select data sets to sort")
 	var algo = flag.String("sort", "", "regex to select sorting algorithms")
-	// TODO: add a 'size' flag to select the data sizes (small, medium, large)
-	// TODO: add a 'file' flag to take a file to be sorted instead of generated data
+	var size = flag.String("size", "medium", "preset name or comma-separated data sizes")
+	var format = flag.String("format", "text", "output format: text, json, or csv")
+	var sample = flag.Bool("sample", false, "randomly sample records from files")
+	var samples = flag.Int("samples", 0, "number of samples to measure")
+	var warmup = flag.Int("warmup", 0, "number of unmeasured sorts before measuring")
+	var minTime = flag.Duration("mintime", 0, "minimum duration of each sample")
+	var seed = flag.Int64("seed", 0, "seed for generating data, or zero for "+generate.SeedEnv)
+	var cpuProfile = flag.String("cpuprofile", "", "directory for CPU profiles")
+	var memProfile = flag.String("memprofile", "", "directory for heap profiles")
+	var save = flag.String("save", "", "file in which to save the results")
+	var baseline = flag.String("baseline", "", "file of saved results to compare against")
+	var threshold = flag.Float64("threshold", 10, "percentage slowdown regarded as a regression")
+	var alpha = flag.Float64("alpha", 0.05, "significance level for detecting regressions")
+	var files fileList
+	flag.Var(&files, "file", "file of records to sort instead of generated data")
 	flag.Parse()
 
 	if *help {
@@ -190,94 +283,203 @@ func main() {
 		os.Exit(0)
 	}
 
-	if *list {
-		fmt.Println("Data sets")
-		for _, dataSetName := range dataSetNames {
-			fmt.Printf("\t%s\n", dataSetName)
+	if *listFlag {
+		list()
+		os.Exit(0)
+	}
+
+	if !report.ValidFormat(*format) {
+		fmt.Printf("unsupported format '%s' in --format flag, expected one of %s\n",
+			*format, strings.Join(report.Formats, ", "))
+		os.Exit(1)
+	}
+
+	if *alpha <= 0 || *alpha >= 1 {
+		fmt.Printf("invalid level %v in --alpha flag, expected between 0 and 1\n", *alpha)
+		os.Exit(1)
+	}
+
+	// start with the profile, then apply the config file, and finally
+	// the flags given on the command line
+	var cfg Config
+	var err error
+	if *configPath != "" {
+		cfg, err = loadConfig(*configPath, *profile)
+	} else if *profile != "" {
+		cfg, err = profileConfig(*profile)
+	} else {
+		cfg, err = profileConfig(defaultProfile)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "size":
+			if cfg.Sizes, err = parseSizes(*size, cfg.Presets); err != nil {
+				fmt.Printf("%s in '%s' of --size flag\n", err, *size)
+				os.Exit(1)
+			}
+		case "file":
+			cfg.Files = files
+		case "sample":
+			cfg.Sample = *sample
+		case "samples":
+			cfg.Samples = *samples
+		case "warmup":
+			cfg.Warmup = *warmup
+		case "mintime":
+			cfg.MinTime = Duration(*minTime)
+		case "seed":
+			cfg.Seed = *seed
 		}
-		fmt.Println("Sorting algorithms")
-		for _, sorterName := range sorterNames {
-			fmt.Printf("\t%s\n", sorterName)
+	})
+	if err := cfg.validate(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var base *report.Report
+	if *baseline != "" {
+		if base, err = report.Load(*baseline); err != nil {
+			fmt.Printf("%s in --baseline flag\n", err)
+			os.Exit(1)
+		}
+	}
+	if cfg.Seed, err = chooseSeed(cfg.Seed, base); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if base != nil && cfg.Seed != base.Metadata.Seed {
+		fmt.Fprintf(os.Stderr, "warning: seed %d differs from the seed %d of the baseline, so the data sets are not the same\n",
+			cfg.Seed, base.Metadata.Seed)
+	}
+
+	if len(cfg.Files) > 0 {
+		// sort the contents of the files instead of generated data
+		cfg.DataSets = nil
+		for _, path := range cfg.Files {
+			records, err := dataset.LoadFile(path)
+			if err != nil {
+				fmt.Printf("%s in files\n", err)
+				os.Exit(1)
+			}
+			name := dataset.FileName(path)
+			if _, exists := dataGenerators[name]; exists {
+				name = fmt.Sprintf("%s#%d", name, len(cfg.DataSets)+1)
+			}
+			cfg.DataSets = append(cfg.DataSets, name)
+			sampled := cfg.Sample
+			dataGenerators[name] = func(r *rand.Rand, size int) []string {
+				return dataset.Fit(r, records, size, sampled)
+			}
 		}
-		os.Exit(0)
 	}
 
 	if *data != "" {
-		re, err := regexp.Compile(strings.ToLower(*data))
-		if err != nil {
+		if cfg.DataSets, err = filterNames(cfg.DataSets, *data); err != nil {
 			fmt.Printf("%s in '%s' of --data flag\n", err, *data)
 			os.Exit(1)
 		}
-		newData := make([]string, 0, len(dataSetNames))
-		for _, dataSetName := range dataSetNames {
-			if idx := re.FindStringIndex(strings.ToLower(dataSetName)); idx != nil {
-				newData = append(newData, dataSetName)
-			}
-		}
-		dataSetNames = newData
 	}
 
 	if *algo != "" {
-		re, err := regexp.Compile(strings.ToLower(*algo))
-		if err != nil {
+		if cfg.Algorithms, err = filterNames(cfg.Algorithms, *algo); err != nil {
 			fmt.Printf("%s in '%s' of --sort flag\n", err, *algo)
 			os.Exit(1)
 		}
-		newSort := make([]string, 0, len(sorterNames))
-		for _, sorterName := range sorterNames {
-			if idx := re.FindStringIndex(strings.ToLower(sorterName)); idx != nil {
-				newSort = append(newSort, sorterName)
-			}
-		}
-		sorterNames = newSort
-	}
-
-	// Avoid recreating the input arrays over and over again.
-	inputSets := make(map[int][]string)
-	for _, size := range sortSizes {
-		inputSets[size] = make([]string, size)
 	}
 
 	// For each type of data set...
 	// and each data set size...
 	// and each sort implementation...
-	// run the sort via the testing package benchmark facility.
-	for _, dataSetName := range dataSetNames {
-		fmt.Printf("%s...\n", dataSetName)
-		for _, size := range sortSizes {
-			fmt.Printf("\t%d...\n", size)
-			dataSet := dataGenerators[dataSetName](size)
-			input := inputSets[size]
-			for _, sorterName := range sorterNames {
-				fmt.Printf("\t\t%-10s:\t", sorterName)
+	// run the sort and measure the time taken.
+	text := *format == "text"
+	runner := bench.Runner{Warmup: cfg.Warmup, Samples: cfg.Samples, MinTime: time.Duration(cfg.MinTime)}
+	profiler, err := bench.NewProfiler(*cpuProfile, *memProfile)
+	if err != nil {
+		fmt.Printf("%s in profile flags\n", err)
+		os.Exit(1)
+	}
+	results := report.Report{Metadata: report.NewMetadata("sortbench " + cfg.Profile)}
+	results.Metadata.Seed = cfg.Seed
+	if text {
+		fmt.Printf("Seed: %d\n", cfg.Seed)
+	}
+	for _, dataSetName := range cfg.DataSets {
+		if text {
+			fmt.Printf("%s...\n", dataSetName)
+		}
+		for _, size := range cfg.Sizes {
+			// each data set and size has its own source, so that the
+			// data does not depend on which others are selected
+			rng := generate.NewRand(cfg.Seed, fmt.Sprintf("%s/%d", dataSetName, size))
+			dataSet := dataGenerators[dataSetName](rng, size)
+			// files may have fewer records than requested
+			if text {
+				fmt.Printf("\t%d...\n", len(dataSet))
+			}
+			for _, sorterName := range cfg.Algorithms {
+				if text {
+					fmt.Printf("\t\t%-10s:\t", sorterName)
+				}
 				sorter := sorters[sorterName]
-				times := new([runCount]int64)
-				for run := 0; run < runCount; run++ {
-					copy(input, dataSet)
-					t1 := time.Now()
-					sorter(input)
-					t2 := time.Now()
-					times[run] = (t2.Sub(t1).Nanoseconds()) / 1000000
+				profile := bench.ProfileName(sorterName, dataSetName, len(dataSet))
+				if err := profiler.Start(profile); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					os.Exit(1)
 				}
-
-				// Find the average of the run times. The run times
-				// should never be more than a couple of minutes,
-				// so these calculations will never overflow.
-				var total int64 = 0
-				var highest int64 = 0
-				var lowest int64 = 2147483648
-				for run := 0; run < runCount; run++ {
-					total += times[run]
-					if times[run] > highest {
-						highest = times[run]
-					}
-					if times[run] < lowest {
-						lowest = times[run]
+				m := runner.Run(dataSet, sorter)
+				if err := profiler.Stop(profile); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					os.Exit(1)
+				}
+				result := report.NewResult(sorterName, dataSetName, len(dataSet), m.Samples,
+					m.Allocs, m.Bytes)
+				result.Iterations = m.Iterations
+				result.PeakHeap = m.PeakHeap
+				results.Results = append(results.Results, result)
+				if text {
+					// Find the lowest, average, and highest run times.
+					lowest, highest := m.Samples[0], m.Samples[0]
+					for _, t := range m.Samples {
+						lowest = min(lowest, t)
+						highest = max(highest, t)
 					}
+					fmt.Printf("%10s %10s %10s (low/avg/high) ±%.1f%%%s\n",
+						formatTime(float64(lowest)), formatTime(result.Mean),
+						formatTime(float64(highest)), result.Margin(), outlierNote(result))
+					fmt.Printf("\t\t%-10s \t%s, %d sorts per sample\n", "", memoryNote(result),
+						m.Iterations)
 				}
-				average := total / runCount
-				fmt.Printf("%4d %4d %4d (low/avg/high) ms\n", lowest, average, highest)
 			}
 		}
 	}
+	if !text {
+		if err := results.Write(os.Stdout, *format); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if *save != "" {
+		if err := results.Save(*save); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if base != nil {
+		// keep the json and csv output free of the comparison
+		out := os.Stdout
+		if !text {
+			out = os.Stderr
+		}
+		deltas := report.Compare(base, &results, *threshold, *alpha)
+		fmt.Fprintln(out)
+		report.WriteDeltas(out, deltas)
+		if n := report.Regressions(deltas); n > 0 {
+			fmt.Fprintf(out, "%d regressions beyond %.1f%%\n", n, *threshold)
+			os.Exit(2)
+		}
+	}
 }
@@ -0,0 +1,32 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package main
+
+import (
+	"testing"
+
+	"github.com/nlfiedler/sortingo/internal/generate"
+	"github.com/nlfiedler/sortingo/internal/report"
+)
+
+func TestChooseSeed(t *testing.T) {
+	t.Setenv(generate.SeedEnv, "")
+	base := &report.Report{Metadata: report.Metadata{Seed: 42}}
+	if seed, err := chooseSeed(0, base); err != nil || seed != 42 {
+		t.Errorf("expected the seed of the baseline, got %d (%v)", seed, err)
+	}
+	if seed, err := chooseSeed(7, base); err != nil || seed != 7 {
+		t.Errorf("expected the given seed, got %d (%v)", seed, err)
+	}
+	if seed, err := chooseSeed(0, nil); err != nil || seed == 0 {
+		t.Errorf("expected a seed chosen from the time, got %d (%v)", seed, err)
+	}
+	t.Setenv(generate.SeedEnv, "9")
+	if seed, err := chooseSeed(0, base); err != nil || seed != 9 {
+		t.Errorf("expected the seed of %s, got %d (%v)", generate.SeedEnv, seed, err)
+	}
+}
@@ -0,0 +1,69 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package bench
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+// heapMetric names the runtime metric for the memory occupied by heap
+// objects, including those not yet collected.
+const heapMetric = "/memory/classes/heap/objects:bytes"
+
+// peakInterval is the time between samples of the heap size while
+// finding the peak.
+const peakInterval = 50 * time.Microsecond
+
+// heapBytes reads the current size of the heap objects.
+func heapBytes(sample []metrics.Sample) uint64 {
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// peakHeap sorts a copy of the data while sampling the size of the
+// heap, returning the largest growth in the heap over its size after
+// collecting garbage before the sort. Since the samples are taken
+// periodically and at the end of the sort, brief peaks in a long sort
+// may be missed, and garbage collected during the sort may offset some
+// of the growth.
+func peakHeap(data []string, sorter func([]string)) int64 {
+	input := make([]string, len(data))
+	copy(input, data)
+	sample := []metrics.Sample{{Name: heapMetric}}
+	runtime.GC()
+	base := heapBytes(sample)
+	done := make(chan struct{})
+	peaks := make(chan uint64)
+	go func() {
+		sample := []metrics.Sample{{Name: heapMetric}}
+		var peak uint64
+		for {
+			select {
+			case <-done:
+				peaks <- peak
+				return
+			default:
+			}
+			peak = max(peak, heapBytes(sample))
+			time.Sleep(peakInterval)
+		}
+	}()
+	sorter(input)
+	peak := heapBytes(sample)
+	close(done)
+	peak = max(peak, <-peaks)
+	runtime.KeepAlive(input)
+	if peak < base {
+		return 0
+	}
+	return int64(peak - base)
+}
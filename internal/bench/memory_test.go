@@ -0,0 +1,33 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package bench
+
+import (
+	"testing"
+	"time"
+)
+
+// held keeps the allocation of the test sort reachable.
+var held []byte
+
+func TestPeakHeap(t *testing.T) {
+	const size = 8 << 20
+	sorter := func(a []string) {
+		// hold a large allocation for some time, then release it
+		held = make([]byte, size)
+		time.Sleep(5 * time.Millisecond)
+		held = nil
+	}
+	// garbage collected during the sort may offset some of the growth
+	peak := peakHeap([]string{"a"}, sorter)
+	if peak < size*3/4 || peak > 2*size {
+		t.Errorf("expected peak of about %d bytes, got %d", size, peak)
+	}
+	if peak := peakHeap([]string{"b", "a"}, func(a []string) { a[0], a[1] = a[1], a[0] }); peak > 64<<10 {
+		t.Errorf("sort without allocation has unexpected peak of %d bytes", peak)
+	}
+}
@@ -0,0 +1,106 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package bench
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+)
+
+// profileRate is the memory profiling rate used when writing heap
+// profiles, fine enough to attribute the allocation of small nodes.
+const profileRate = 4096
+
+// Profiler writes pprof profiles covering the measurement of each
+// algorithm, data set, and size, which are named by ProfileName.
+type Profiler struct {
+	cpuDir  string   // directory for CPU profiles, or empty for none
+	heapDir string   // directory for heap profiles, or empty for none
+	cpuFile *os.File // CPU profile being written
+}
+
+// NewProfiler constructs a Profiler writing CPU profiles to cpuDir and
+// heap profiles to heapDir, either of which may be empty to write no
+// profiles of that kind. The directories are created if needed. Heap
+// profiling is enabled at a finer rate than the default, and should be
+// started before any measurements are made. The allocations in each
+// heap profile are cumulative, such that the allocations of a single
+// measurement are seen by giving the profile of the previous one as
+// the -diff_base of the pprof tool.
+func NewProfiler(cpuDir, heapDir string) (*Profiler, error) {
+	for _, dir := range []string{cpuDir, heapDir} {
+		if dir != "" {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if heapDir != "" {
+		runtime.MemProfileRate = profileRate
+	}
+	return &Profiler{cpuDir: cpuDir, heapDir: heapDir}, nil
+}
+
+// ProfileName forms the base name of the profiles for one measurement,
+// replacing any characters unsuitable for a file name.
+func ProfileName(algorithm, dataSet string, size int) string {
+	name := fmt.Sprintf("%s-%s-%d", algorithm, dataSet, size)
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// Start begins CPU profiling of the named measurement, if enabled.
+func (p *Profiler) Start(name string) error {
+	if p.cpuDir == "" {
+		return nil
+	}
+	f, err := os.Create(filepath.Join(p.cpuDir, name+".cpu.pprof"))
+	if err != nil {
+		return err
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	p.cpuFile = f
+	return nil
+}
+
+// Stop ends CPU profiling of the named measurement, and writes its
+// heap profile, if either is enabled.
+func (p *Profiler) Stop(name string) error {
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+		err := p.cpuFile.Close()
+		p.cpuFile = nil
+		if err != nil {
+			return err
+		}
+	}
+	if p.heapDir == "" {
+		return nil
+	}
+	f, err := os.Create(filepath.Join(p.heapDir, name+".heap.pprof"))
+	if err != nil {
+		return err
+	}
+	// collect garbage to bring the heap statistics up to date
+	runtime.GC()
+	if err := pprof.Lookup("heap").WriteTo(f, 0); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,57 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package bench
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestProfileName(t *testing.T) {
+	if name := ProfileName("2PivotQ", "words.txt#2", 100); name != "2PivotQ-words.txt_2-100" {
+		t.Errorf("unexpected profile name %q", name)
+	}
+}
+
+func TestProfiler(t *testing.T) {
+	rate := runtime.MemProfileRate
+	defer func() { runtime.MemProfileRate = rate }()
+	dir := t.TempDir()
+	cpuDir, heapDir := filepath.Join(dir, "cpu"), filepath.Join(dir, "heap")
+	p, err := NewProfiler(cpuDir, heapDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Start("Sort-Data-10"); err != nil {
+		t.Fatal(err)
+	}
+	r := Runner{Samples: 1, MinTime: 10 * time.Millisecond}
+	r.Run([]string{"b", "c", "a"}, sort.Strings)
+	if err := p.Stop("Sort-Data-10"); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{
+		filepath.Join(cpuDir, "Sort-Data-10.cpu.pprof"),
+		filepath.Join(heapDir, "Sort-Data-10.heap.pprof"),
+	} {
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("expected profile %s to be written (%v)", path, err)
+		}
+	}
+	// profiles are optional
+	p, err = NewProfiler("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Start("x"); err != nil || p.Stop("x") != nil {
+		t.Error("disabled profiler should do nothing")
+	}
+}
@@ -0,0 +1,123 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+// Package bench measures the time taken by the sorts for the benchmark
+// commands. Each measurement starts with warm-up runs, then calibrates
+// the number of sorts in each sample to reach a target duration, so
+// that sorts of a few strings are timed as accurately as those of
+// millions. The inputs for a batch of sorts are copied before the timer
+// starts, keeping both the copying and the timer overhead out of the
+// measurement, and the garbage collector runs before each sample so
+// that garbage from one sample is not collected during the next. The
+// allocations are counted over the samples, while the peak size of the
+// heap is found by a separate, untimed sort.
+package bench
+
+import (
+	"runtime"
+	"time"
+)
+
+// Runner measures sorts according to its settings.
+type Runner struct {
+	Warmup  int           // number of sorts run before measuring
+	Samples int           // number of samples to measure
+	MinTime time.Duration // minimum duration of each sample
+}
+
+// Measurement holds the results of measuring one sort of one input.
+type Measurement struct {
+	Iterations int             // number of sorts in each sample
+	Samples    []time.Duration // average time of one sort in each sample
+	Allocs     int64           // average allocations per sort
+	Bytes      int64           // average bytes allocated per sort
+	PeakHeap   int64           // largest growth of the heap during a sort
+}
+
+// maxBatch is the largest number of strings prepared for sorting in one
+// batch, which bounds the memory used to time the smaller inputs.
+const maxBatch = 1 << 16
+
+// maxIterations limits the number of sorts in each sample.
+const maxIterations = 1e9
+
+// batch holds copies of the input to be sorted one after another.
+type batch struct {
+	data   []string   // input to be sorted
+	inputs [][]string // copies of the input, allocated as needed
+	limit  int        // maximum number of copies
+}
+
+// newBatch prepares to sort copies of the data.
+func newBatch(data []string) *batch {
+	return &batch{data: data, limit: max(1, maxBatch/max(1, len(data)))}
+}
+
+// run sorts n copies of the input, returning the total time taken by
+// the sorts alone.
+func (b *batch) run(sorter func([]string), n int) time.Duration {
+	var elapsed time.Duration
+	for n > 0 {
+		k := min(n, b.limit)
+		for len(b.inputs) < k {
+			b.inputs = append(b.inputs, make([]string, len(b.data)))
+		}
+		for _, input := range b.inputs[:k] {
+			copy(input, b.data)
+		}
+		start := time.Now()
+		for _, input := range b.inputs[:k] {
+			sorter(input)
+		}
+		elapsed += time.Since(start)
+		n -= k
+	}
+	return elapsed
+}
+
+// calibrate finds the number of sorts needed for a sample to take at
+// least the minimum time, in the manner of the testing package.
+func (r Runner) calibrate(b *batch, sorter func([]string)) int {
+	n := 1
+	for {
+		elapsed := b.run(sorter, n)
+		if elapsed >= r.MinTime || n >= maxIterations {
+			return n
+		}
+		// aim 20% beyond the minimum, growing by no more than 100x
+		next := n * 100
+		if elapsed > 0 {
+			next = min(next, int(1.2*float64(r.MinTime)*float64(n)/float64(elapsed))+1)
+		}
+		n = min(max(next, n+1), maxIterations)
+	}
+}
+
+// Run measures the sorting of the data, which is left unchanged.
+func (r Runner) Run(data []string, sorter func([]string)) Measurement {
+	b := newBatch(data)
+	b.run(sorter, r.Warmup)
+	n := r.calibrate(b, sorter)
+	m := Measurement{Iterations: n, Samples: make([]time.Duration, max(1, r.Samples))}
+	var before, after runtime.MemStats
+	var allocs, bytes uint64
+	for i := range m.Samples {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		elapsed := b.run(sorter, n)
+		runtime.ReadMemStats(&after)
+		m.Samples[i] = elapsed / time.Duration(n)
+		allocs += after.Mallocs - before.Mallocs
+		bytes += after.TotalAlloc - before.TotalAlloc
+	}
+	// the copies of the input were allocated during calibration, which
+	// ended with a run of n sorts, so only the sorts allocate here
+	sorts := uint64(n * len(m.Samples))
+	m.Allocs = int64(allocs / sorts)
+	m.Bytes = int64(bytes / sorts)
+	m.PeakHeap = peakHeap(data, sorter)
+	return m
+}
@@ -0,0 +1,88 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package bench
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestRunnerCalibrates(t *testing.T) {
+	data := []string{"c", "a", "b", "e", "d"}
+	calls := 0
+	sorter := func(a []string) {
+		if !sort.StringsAreSorted(a) {
+			calls++
+		}
+		sort.Strings(a)
+	}
+	r := Runner{Warmup: 3, Samples: 4, MinTime: 5 * time.Millisecond}
+	m := r.Run(data, sorter)
+	if m.Iterations < 100 {
+		t.Errorf("expected many iterations of a tiny sort, got %d", m.Iterations)
+	}
+	if len(m.Samples) != 4 {
+		t.Fatalf("expected 4 samples, got %d", len(m.Samples))
+	}
+	for _, d := range m.Samples {
+		if d <= 0 || d >= time.Millisecond {
+			t.Errorf("unexpected time per sort %v", d)
+		}
+	}
+	// every sort is given a fresh copy of the unsorted input
+	if calls < 3+4*m.Iterations {
+		t.Errorf("expected every sort to see unsorted input, got %d of at least %d",
+			calls, 3+4*m.Iterations)
+	}
+	if data[0] != "c" || data[4] != "d" {
+		t.Error("input data was modified")
+	}
+}
+
+func TestRunnerSlowSort(t *testing.T) {
+	sorter := func(a []string) {
+		time.Sleep(2 * time.Millisecond)
+	}
+	r := Runner{Samples: 3, MinTime: time.Millisecond}
+	m := r.Run([]string{"a"}, sorter)
+	if m.Iterations != 1 {
+		t.Errorf("expected one iteration of a slow sort, got %d", m.Iterations)
+	}
+	for _, d := range m.Samples {
+		if d < 2*time.Millisecond {
+			t.Errorf("sample %v shorter than the sort", d)
+		}
+	}
+}
+
+func TestRunnerAllocations(t *testing.T) {
+	var sink [][]byte
+	sorter := func(a []string) {
+		sink = append(sink[:0], make([]byte, 1024))
+	}
+	r := Runner{Samples: 2, MinTime: time.Millisecond}
+	m := r.Run(make([]string, 10), sorter)
+	if m.Allocs < 1 || m.Bytes < 1024 {
+		t.Errorf("expected at least one allocation of 1024 bytes per sort, got %d of %d bytes",
+			m.Allocs, m.Bytes)
+	}
+}
+
+func TestBatchLimit(t *testing.T) {
+	data := make([]string, maxBatch/4)
+	b := newBatch(data)
+	b.run(func([]string) {}, 10)
+	if len(b.inputs) != 4 {
+		t.Errorf("expected 4 copies of the input, got %d", len(b.inputs))
+	}
+	b = newBatch(make([]string, 2*maxBatch))
+	b.run(func([]string) {}, 3)
+	if len(b.inputs) != 1 {
+		t.Errorf("expected one copy of a large input, got %d", len(b.inputs))
+	}
+}
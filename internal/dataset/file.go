@@ -0,0 +1,94 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+// Package dataset provides the input data for the benchmark commands.
+package dataset
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	gosort "sort"
+	"strings"
+)
+
+// gzipMagic is the header that begins every gzip-compressed file.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// LoadFile reads the lines of the named file. The file may be
+// compressed with gzip, which is detected from its contents. If the
+// file contains any null characters, its records are separated by
+// nulls, otherwise by newlines, with any carriage returns removed. A
+// trailing separator does not produce an empty record.
+func LoadFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, gzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, err
+		}
+	}
+	return splitRecords(string(data)), nil
+}
+
+// splitRecords splits the text into records as described for LoadFile.
+func splitRecords(text string) []string {
+	sep := "\n"
+	if strings.IndexByte(text, 0) >= 0 {
+		sep = "\x00"
+	}
+	text = strings.TrimSuffix(text, sep)
+	if text == "" {
+		return []string{}
+	}
+	records := strings.Split(text, sep)
+	if sep == "\n" {
+		for i, r := range records {
+			records[i] = strings.TrimSuffix(r, "\r")
+		}
+	}
+	return records
+}
+
+// FileName returns the data set name for the file: its base name,
+// without a .gz extension.
+func FileName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), ".gz")
+}
+
+// Fit returns size records from those given, either the first size
+// records, or if sample is true, a selection of them chosen using r
+// that retains their original order. If there are no more than size
+// records, all of them are returned.
+func Fit(r *rand.Rand, records []string, size int, sample bool) []string {
+	if len(records) <= size {
+		return records
+	}
+	if !sample {
+		return records[:size]
+	}
+	picks := r.Perm(len(records))[:size]
+	gosort.Ints(picks)
+	result := make([]string, size)
+	for i, p := range picks {
+		result[i] = records[p]
+	}
+	return result
+}
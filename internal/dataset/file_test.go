@@ -0,0 +1,95 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package dataset
+
+import (
+	"compress/gzip"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// checkRecords compares the records against the expected values.
+func checkRecords(t *testing.T, name string, records, expected []string) {
+	if len(records) != len(expected) {
+		t.Fatalf("%s: expected %d records, got %d: %q", name, len(expected), len(records), records)
+	}
+	for i, r := range records {
+		if r != expected[i] {
+			t.Errorf("%s: expected %q at %d, got %q", name, expected[i], i, r)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	expected := []string{"banana", "apple", "", "cherry pie"}
+	files := map[string]string{
+		"lines.txt":    "banana\napple\n\ncherry pie\n",
+		"crlf.txt":     "banana\r\napple\r\n\r\ncherry pie",
+		"records.nul":  "banana\x00apple\x00\x00cherry pie\x00",
+		"nul-lines.db": "banana\x00apple\x00\x00cherry pie",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		records, err := LoadFile(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		checkRecords(t, name, records, expected)
+	}
+	// compressed files are detected by their contents
+	path := filepath.Join(dir, "lines.txt.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := gzip.NewWriter(f)
+	zw.Write([]byte(files["records.nul"]))
+	zw.Close()
+	f.Close()
+	records, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRecords(t, "gzip", records, expected)
+	if FileName(path) != "lines.txt" {
+		t.Errorf("unexpected data set name %q", FileName(path))
+	}
+	// empty and missing files
+	path = filepath.Join(dir, "empty")
+	os.WriteFile(path, nil, 0644)
+	if records, err := LoadFile(path); err != nil || len(records) != 0 {
+		t.Errorf("empty file should have no records, got %q (%v)", records, err)
+	}
+	if _, err := LoadFile(filepath.Join(dir, "missing")); err == nil {
+		t.Error("missing file should return an error")
+	}
+}
+
+func TestFit(t *testing.T) {
+	records := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	r := rand.New(rand.NewSource(1))
+	checkRecords(t, "truncate", Fit(r, records, 3, false), records[:3])
+	checkRecords(t, "short", Fit(r, records, 10, true), records)
+	sample := Fit(r, records, 5, true)
+	if len(sample) != 5 {
+		t.Fatalf("expected 5 records in sample, got %d", len(sample))
+	}
+	for i := 1; i < len(sample); i++ {
+		if sample[i-1] >= sample[i] {
+			t.Errorf("sample does not retain order: %q", sample)
+		}
+	}
+	// the same seed selects the same sample
+	first := Fit(rand.New(rand.NewSource(7)), records, 5, true)
+	checkRecords(t, "repeat", Fit(rand.New(rand.NewSource(7)), records, 5, true), first)
+}
@@ -0,0 +1,156 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+// Package generate creates the synthetic data sets sorted by the
+// benchmarks and the tests. Each generator produces a data set of the
+// requested size, drawing all of its random choices from the given
+// source, such that the same seed always produces the same data. The
+// generators are registered by name in Generators. Data sets that
+// depend upon the sort algorithms themselves, such as worst-case
+// inputs, are left to the benchmark command, so that the tests of the
+// sort package are free to use this package.
+package generate
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Generator creates a data set of the given number of strings, using
+// the source for any random choices.
+type Generator func(r *rand.Rand, size int) []string
+
+// Names lists the generators in their usual run order.
+var Names = []string{"Repeat", "RepeatCycle", "Random", "PseudoWords", "SmallAlphabet", "Genome",
+	"Zipf", "URLs", "Paths", "Sorted", "Reversed", "NearlySorted", "Sawtooth", "OrganPipe",
+	"UTF8", "DNAReads"}
+
+// Generators maps the generator names to their functions.
+var Generators = map[string]Generator{
+	"Repeat":        Repeated,
+	"RepeatCycle":   RepeatedCycle,
+	"Random":        Random,
+	"PseudoWords":   PseudoWords,
+	"SmallAlphabet": SmallAlphabet,
+	"Genome":        Genome,
+	"Zipf":          ZipfWords,
+	"URLs":          URLs,
+	"Paths":         Paths,
+	"Sorted":        Sorted,
+	"Reversed":      Reversed,
+	"NearlySorted":  NearlySorted,
+	"Sawtooth":      Sawtooth,
+	"OrganPipe":     OrganPipe,
+	"UTF8":          MultiScript,
+	"DNAReads":      DNAReads,
+}
+
+// Repeated generates the same string of 100 letters over and over.
+func Repeated(r *rand.Rand, size int) []string {
+	repeatedStrings := make([]string, size)
+	a100 := strings.Repeat("A", 100)
+	for idx := range repeatedStrings {
+		repeatedStrings[idx] = a100
+	}
+	return repeatedStrings
+}
+
+// RepeatedCycle generates a repeating cycle of strings, each one letter
+// longer than the last, from 1 to 100 letters.
+func RepeatedCycle(r *rand.Rand, size int) []string {
+	a100 := strings.Repeat("A", 100)
+	strs := make([]string, len(a100))
+	for i := range strs {
+		strs[i] = a100[0 : i+1]
+	}
+	repeatedCycleStrings := make([]string, size)
+	c := 0
+	for i := range repeatedCycleStrings {
+		repeatedCycleStrings[i] = strs[c]
+		if c++; c >= len(strs) {
+			c = 0
+		}
+	}
+	return repeatedCycleStrings
+}
+
+// Random generates random strings of printable characters, each of
+// length 100.
+func Random(r *rand.Rand, size int) []string {
+	randomStrings := make([]string, size)
+	for i := range randomStrings {
+		var sb strings.Builder
+		sb.Grow(100)
+		for j := 0; j < 100; j++ {
+			sb.WriteByte(byte(' ' + r.Intn(95)))
+		}
+		randomStrings[i] = sb.String()
+	}
+	return randomStrings
+}
+
+// PseudoWords generates unique pseudo words of 1 to 27 lowercase
+// letters.
+func PseudoWords(r *rand.Rand, size int) []string {
+	uniqueWords := make([]string, size)
+	wordExists := make(map[string]bool)
+	for i := range uniqueWords {
+		var s string
+		// Loop until a unique random word is generated.
+		for {
+			s = pseudoWord(r)
+			if !wordExists[s] {
+				break
+			}
+		}
+		uniqueWords[i] = s
+		wordExists[s] = true
+	}
+	return uniqueWords
+}
+
+// pseudoWord generates a random word of 1 to 27 lowercase letters.
+func pseudoWord(r *rand.Rand) string {
+	l := 1 + r.Intn(27)
+	var sb strings.Builder
+	sb.Grow(l)
+	for j := 0; j < l; j++ {
+		sb.WriteByte(byte('a' + r.Intn(26)))
+	}
+	return sb.String()
+}
+
+// SmallAlphabet generates random strings of 1 to 100 characters, drawn
+// from an alphabet of nine letters.
+func SmallAlphabet(r *rand.Rand, size int) []string {
+	smallAlphaStrings := make([]string, size)
+	for i := range smallAlphaStrings {
+		l := 1 + r.Intn(100)
+		var sb strings.Builder
+		sb.Grow(l)
+		for j := 0; j < l; j++ {
+			sb.WriteByte(byte('a' + r.Intn(9)))
+		}
+		smallAlphaStrings[i] = sb.String()
+	}
+	return smallAlphaStrings
+}
+
+// Genome generates random "genome" strings, each of length 9,
+// consisting of the letters a, c, g, t.
+func Genome(r *rand.Rand, size int) []string {
+	const bases = "acgt"
+	genomeStrings := make([]string, size)
+	for i := range genomeStrings {
+		var sb strings.Builder
+		sb.Grow(9)
+		for j := 0; j < 9; j++ {
+			sb.WriteByte(bases[r.Intn(len(bases))])
+		}
+		genomeStrings[i] = sb.String()
+	}
+	return genomeStrings
+}
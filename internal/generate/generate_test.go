@@ -0,0 +1,102 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package generate
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// newRand returns a source with a fixed seed.
+func newRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
+
+func TestGeneratorsRegistered(t *testing.T) {
+	if len(Names) != len(Generators) {
+		t.Fatalf("%d names for %d generators", len(Names), len(Generators))
+	}
+	r := newRand()
+	for _, name := range Names {
+		gen, ok := Generators[name]
+		if !ok {
+			t.Errorf("generator %s is not registered", name)
+			continue
+		}
+		for _, size := range []int{0, 1, 2, 3, 100, 1000} {
+			data := gen(r, size)
+			if len(data) != size {
+				t.Errorf("%s generated %d strings, expected %d", name, len(data), size)
+			}
+			for _, s := range data {
+				if !utf8.ValidString(s) || strings.ContainsAny(s, "\x00\n") {
+					t.Fatalf("%s generated invalid string %q", name, s)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratorsSeeded(t *testing.T) {
+	for _, name := range Names {
+		gen := Generators[name]
+		a := gen(rand.New(rand.NewSource(42)), 500)
+		b := gen(rand.New(rand.NewSource(42)), 500)
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("%s generated different data from the same seed", name)
+		}
+	}
+	a := Random(rand.New(rand.NewSource(1)), 10)
+	b := Random(rand.New(rand.NewSource(2)), 10)
+	if reflect.DeepEqual(a, b) {
+		t.Error("different seeds generated the same data")
+	}
+}
+
+// checkStrings verifies that every string has a length within the
+// bounds, and consists only of the given characters.
+func checkStrings(t *testing.T, name string, data []string, minLen, maxLen int, chars string) {
+	for _, s := range data {
+		if len(s) < minLen || len(s) > maxLen {
+			t.Fatalf("%s: string %q not of length %d to %d", name, s, minLen, maxLen)
+		}
+		if i := strings.IndexFunc(s, func(r rune) bool { return !strings.ContainsRune(chars, r) }); i >= 0 {
+			t.Fatalf("%s: string %q has unexpected character at %d", name, s, i)
+		}
+	}
+}
+
+func TestGeneratorContents(t *testing.T) {
+	const lower = "abcdefghijklmnopqrstuvwxyz"
+	var printable strings.Builder
+	for c := ' '; c <= '~'; c++ {
+		printable.WriteRune(c)
+	}
+	r := newRand()
+	checkStrings(t, "Repeated", Repeated(r, 50), 100, 100, "A")
+	checkStrings(t, "Random", Random(r, 500), 100, 100, printable.String())
+	checkStrings(t, "PseudoWords", PseudoWords(r, 500), 1, 27, lower)
+	checkStrings(t, "SmallAlphabet", SmallAlphabet(r, 500), 1, 100, lower[:9])
+	checkStrings(t, "Genome", Genome(r, 500), 9, 9, "acgt")
+	cycle := RepeatedCycle(r, 250)
+	for i, s := range cycle {
+		if len(s) != i%100+1 {
+			t.Fatalf("RepeatedCycle: string %d has length %d", i, len(s))
+		}
+	}
+	words := PseudoWords(r, 2000)
+	seen := make(map[string]bool)
+	for _, w := range words {
+		if seen[w] {
+			t.Fatalf("PseudoWords: duplicate word %q", w)
+		}
+		seen[w] = true
+	}
+}
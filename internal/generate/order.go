@@ -0,0 +1,82 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package generate
+
+// Generators of data sets having a particular order, which exercise the
+// best and worst cases of many sorts. Each arranges unique pseudo words,
+// such that the order is due entirely to the arrangement.
+
+import (
+	"math"
+	"math/rand"
+	gosort "sort"
+)
+
+// Sorted generates unique pseudo words in ascending order.
+func Sorted(r *rand.Rand, size int) []string {
+	words := PseudoWords(r, size)
+	gosort.Strings(words)
+	return words
+}
+
+// Reversed generates unique pseudo words in descending order.
+func Reversed(r *rand.Rand, size int) []string {
+	words := Sorted(r, size)
+	for i, j := 0, len(words)-1; i < j; i, j = i+1, j-1 {
+		words[i], words[j] = words[j], words[i]
+	}
+	return words
+}
+
+// NearlySorted generates unique pseudo words in ascending order, except
+// for one percent of them (at least one pair) having been swapped with
+// other randomly chosen words.
+func NearlySorted(r *rand.Rand, size int) []string {
+	words := Sorted(r, size)
+	Perturb(r, words, max(1, size/100))
+	return words
+}
+
+// Perturb swaps k randomly chosen pairs of elements of the data.
+func Perturb(r *rand.Rand, data []string, k int) {
+	if len(data) < 2 {
+		return
+	}
+	for ; k > 0; k-- {
+		i, j := r.Intn(len(data)), r.Intn(len(data))
+		data[i], data[j] = data[j], data[i]
+	}
+}
+
+// Sawtooth generates unique pseudo words arranged in ascending runs,
+// each of which spans nearly the full range of the words. There are
+// about as many runs as words in each run.
+func Sawtooth(r *rand.Rand, size int) []string {
+	words := Sorted(r, size)
+	teeth := max(1, int(math.Sqrt(float64(size))))
+	result := make([]string, 0, size)
+	for t := 0; t < teeth; t++ {
+		for i := t; i < size; i += teeth {
+			result = append(result, words[i])
+		}
+	}
+	return result
+}
+
+// OrganPipe generates unique pseudo words that ascend through the first
+// half of the data set, and then descend through the second half.
+func OrganPipe(r *rand.Rand, size int) []string {
+	words := Sorted(r, size)
+	result := make([]string, 0, size)
+	for i := 0; i < size; i += 2 {
+		result = append(result, words[i])
+	}
+	for i := size - 1 - size%2; i > 0; i -= 2 {
+		result = append(result, words[i])
+	}
+	return result
+}
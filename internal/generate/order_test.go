@@ -0,0 +1,85 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package generate
+
+import (
+	"sort"
+	"testing"
+)
+
+// runs returns the number of ascending runs in the data.
+func runs(data []string) int {
+	if len(data) == 0 {
+		return 0
+	}
+	count := 1
+	for i := 1; i < len(data); i++ {
+		if data[i] < data[i-1] {
+			count++
+		}
+	}
+	return count
+}
+
+// checkPermutation verifies that the data consists of the sorted words.
+func checkPermutation(t *testing.T, name string, data, sorted []string) {
+	copied := make([]string, len(data))
+	copy(copied, data)
+	sort.Strings(copied)
+	for i := range copied {
+		if copied[i] != sorted[i] {
+			t.Fatalf("%s: not a permutation of the sorted words", name)
+		}
+	}
+}
+
+func TestOrders(t *testing.T) {
+	const size = 1000
+	sorted := Sorted(newRand(), size)
+	if !sort.StringsAreSorted(sorted) {
+		t.Error("Sorted: words not sorted")
+	}
+	reversed := Reversed(newRand(), size)
+	checkPermutation(t, "Reversed", reversed, sorted)
+	if runs(reversed) != size {
+		t.Errorf("Reversed: expected %d runs, got %d", size, runs(reversed))
+	}
+	nearly := NearlySorted(newRand(), size)
+	checkPermutation(t, "NearlySorted", nearly, sorted)
+	if n := runs(nearly); n < 2 || n > 1+2*size/100 {
+		t.Errorf("NearlySorted: unexpected number of runs %d", n)
+	}
+	sawtooth := Sawtooth(newRand(), size)
+	checkPermutation(t, "Sawtooth", sawtooth, sorted)
+	if n := runs(sawtooth); n != 31 {
+		t.Errorf("Sawtooth: expected 31 runs, got %d", n)
+	}
+	for _, n := range []int{size, size - 1} {
+		pipe := OrganPipe(newRand(), n)
+		checkPermutation(t, "OrganPipe", pipe, Sorted(newRand(), n))
+		peak := 0
+		for i, s := range pipe {
+			if s > pipe[peak] {
+				peak = i
+			}
+		}
+		if peak < n/2-1 || !sort.StringsAreSorted(pipe[:peak+1]) || runs(pipe[peak:]) != n-peak {
+			t.Errorf("OrganPipe: size %d does not ascend and then descend", n)
+		}
+	}
+}
+
+func TestPerturb(t *testing.T) {
+	data := []string{"a", "b", "c", "d"}
+	Perturb(newRand(), data, 0)
+	if runs(data) != 1 {
+		t.Error("no perturbations should leave data in order")
+	}
+	single := []string{"a"}
+	Perturb(newRand(), single, 5)
+	Perturb(newRand(), nil, 5)
+}
@@ -0,0 +1,47 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package generate
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+)
+
+// SeedEnv names the environment variable that provides the seed for
+// generating data when none is given on the command line, for both the
+// benchmark command and the tests.
+const SeedEnv = "SORTINGO_SEED"
+
+// ChooseSeed returns the seed to use for generating data: the given
+// seed if it is not zero, or else the value of the SORTINGO_SEED
+// environment variable if it is set, or else a seed based on the time.
+func ChooseSeed(seed int64) (int64, error) {
+	if seed != 0 {
+		return seed, nil
+	}
+	if value := os.Getenv(SeedEnv); value != "" {
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid seed '%s' in %s", value, SeedEnv)
+		}
+		return seed, nil
+	}
+	return time.Now().UnixNano(), nil
+}
+
+// NewRand returns a source of random values derived from the seed and
+// the name, such that each named use of the seed makes the same choices
+// regardless of which others are made before it.
+func NewRand(seed int64, name string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return rand.New(rand.NewSource(seed ^ int64(h.Sum64())))
+}
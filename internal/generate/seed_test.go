@@ -0,0 +1,45 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package generate
+
+import (
+	"testing"
+)
+
+func TestChooseSeed(t *testing.T) {
+	t.Setenv(SeedEnv, "")
+	if seed, err := ChooseSeed(42); err != nil || seed != 42 {
+		t.Errorf("expected the given seed, got %d (%v)", seed, err)
+	}
+	if seed, err := ChooseSeed(0); err != nil || seed == 0 {
+		t.Errorf("expected a seed from the time, got %d (%v)", seed, err)
+	}
+	t.Setenv(SeedEnv, "-17")
+	if seed, err := ChooseSeed(0); err != nil || seed != -17 {
+		t.Errorf("expected the seed from %s, got %d (%v)", SeedEnv, seed, err)
+	}
+	if seed, err := ChooseSeed(5); err != nil || seed != 5 {
+		t.Errorf("the given seed should take precedence, got %d (%v)", seed, err)
+	}
+	t.Setenv(SeedEnv, "soon")
+	if _, err := ChooseSeed(0); err == nil {
+		t.Error("expected error for invalid seed")
+	}
+}
+
+func TestNewRand(t *testing.T) {
+	a := NewRand(1, "Random/1000").Int63()
+	if b := NewRand(1, "Random/1000").Int63(); a != b {
+		t.Errorf("same seed and name should repeat, got %d and %d", a, b)
+	}
+	if b := NewRand(1, "Random/100").Int63(); a == b {
+		t.Error("different names should not repeat")
+	}
+	if b := NewRand(2, "Random/1000").Int63(); a == b {
+		t.Error("different seeds should not repeat")
+	}
+}
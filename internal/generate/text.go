@@ -0,0 +1,168 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package generate
+
+// Generators of data sets resembling real text, with the skewed
+// frequencies, long common prefixes, and multi-byte characters that
+// are poorly represented by uniformly random strings.
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// zipfExponent determines the skew of the word frequencies generated
+// by ZipfWords; natural language is close to one.
+const zipfExponent = 1.1
+
+// ZipfWords generates pseudo words whose frequencies follow Zipf's law,
+// drawn from a vocabulary one eighth the size of the data set. A few
+// words appear very often, while most appear once or not at all.
+func ZipfWords(r *rand.Rand, size int) []string {
+	vocabulary := PseudoWords(r, max(2, size/8))
+	zipf := rand.NewZipf(r, zipfExponent, 1, uint64(len(vocabulary)-1))
+	words := make([]string, size)
+	for i := range words {
+		words[i] = vocabulary[zipf.Uint64()]
+	}
+	return words
+}
+
+// URLs generates web addresses on a handful of hosts, with paths of one
+// to six segments drawn from a small vocabulary, and in some cases a
+// numeric query. Most of the addresses share a long prefix with many
+// others.
+func URLs(r *rand.Rand, size int) []string {
+	hosts := PseudoWords(r, 8)
+	for i, host := range hosts {
+		scheme := "https://www."
+		if i%4 == 3 {
+			scheme = "http://"
+		}
+		hosts[i] = scheme + host + ".com/"
+	}
+	segments := PseudoWords(r, 64)
+	urls := make([]string, size)
+	for i := range urls {
+		var sb strings.Builder
+		sb.WriteString(hosts[r.Intn(len(hosts))])
+		depth := 1 + r.Intn(6)
+		for d := 0; d < depth; d++ {
+			if d > 0 {
+				sb.WriteByte('/')
+			}
+			sb.WriteString(segments[r.Intn(len(segments))])
+		}
+		if r.Intn(3) == 0 {
+			sb.WriteString("?id=")
+			sb.WriteString(strconv.Itoa(r.Intn(100000)))
+		}
+		urls[i] = sb.String()
+	}
+	return urls
+}
+
+// pathRoots are the common beginnings of the paths generated by Paths.
+var pathRoots = []string{
+	"/home/user/src/github.com/",
+	"/usr/local/share/",
+	"/var/lib/containers/storage/overlay/",
+	"/opt/application/releases/",
+}
+
+// pathExtensions are the file name extensions used by Paths.
+var pathExtensions = []string{".go", ".c", ".h", ".txt", ".json", ".md", ""}
+
+// Paths generates file paths beneath a few deep root directories, with
+// one to eight directories drawn from a small vocabulary, and a file
+// name with one of several extensions.
+func Paths(r *rand.Rand, size int) []string {
+	dirs := PseudoWords(r, 32)
+	names := PseudoWords(r, 256)
+	paths := make([]string, size)
+	for i := range paths {
+		var sb strings.Builder
+		sb.WriteString(pathRoots[r.Intn(len(pathRoots))])
+		depth := 1 + r.Intn(8)
+		for d := 0; d < depth; d++ {
+			sb.WriteString(dirs[r.Intn(len(dirs))])
+			sb.WriteByte('/')
+		}
+		sb.WriteString(names[r.Intn(len(names))])
+		sb.WriteString(pathExtensions[r.Intn(len(pathExtensions))])
+		paths[i] = sb.String()
+	}
+	return paths
+}
+
+// scripts are the ranges of letters from which MultiScript draws the
+// characters of each word, encoded in one to four bytes.
+var scripts = []struct {
+	lo, hi rune
+}{
+	{'a', 'z'},         // Latin
+	{0xE0, 0xFF},       // Latin-1 Supplement
+	{0x3B1, 0x3C9},     // Greek
+	{0x430, 0x44F},     // Cyrillic
+	{0x5D0, 0x5EA},     // Hebrew
+	{0x627, 0x64A},     // Arabic
+	{0x905, 0x939},     // Devanagari
+	{0x3041, 0x3096},   // Hiragana
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xAC00, 0xD7A3},   // Hangul Syllables
+	{0x1F600, 0x1F64F}, // Emoticons
+}
+
+// MultiScript generates UTF-8 text of one to four words, separated by
+// spaces, each of one to ten characters drawn from a randomly chosen
+// script.
+func MultiScript(r *rand.Rand, size int) []string {
+	text := make([]string, size)
+	for i := range text {
+		var sb strings.Builder
+		count := 1 + r.Intn(4)
+		for w := 0; w < count; w++ {
+			if w > 0 {
+				sb.WriteByte(' ')
+			}
+			script := scripts[r.Intn(len(scripts))]
+			length := 1 + r.Intn(10)
+			for c := 0; c < length; c++ {
+				sb.WriteRune(script.lo + rune(r.Intn(int(script.hi-script.lo+1))))
+			}
+		}
+		text[i] = sb.String()
+	}
+	return text
+}
+
+// readCoverage is the average number of reads covering each base of
+// the reference sequence from which DNAReads are taken.
+const readCoverage = 30
+
+// DNAReads generates reads of 100 to 300 bases taken from random places
+// in a random reference sequence, as from sequencing the sequence with
+// high coverage. Reads starting at nearby places share long substrings,
+// and those starting at the same place share long prefixes. The reads
+// share the memory of the reference sequence.
+func DNAReads(r *rand.Rand, size int) []string {
+	const minLen, maxLen = 100, 300
+	refLen := max(2*maxLen, size*(minLen+maxLen)/2/readCoverage)
+	ref := make([]byte, refLen)
+	for i := range ref {
+		ref[i] = "acgt"[r.Intn(4)]
+	}
+	reference := string(ref)
+	reads := make([]string, size)
+	for i := range reads {
+		length := minLen + r.Intn(maxLen-minLen+1)
+		start := r.Intn(refLen - length + 1)
+		reads[i] = reference[start : start+length]
+	}
+	return reads
+}
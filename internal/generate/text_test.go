@@ -0,0 +1,107 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package generate
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestZipfWords(t *testing.T) {
+	words := ZipfWords(newRand(), 8000)
+	counts := make(map[string]int)
+	for _, w := range words {
+		counts[w]++
+	}
+	if len(counts) > 1000 {
+		t.Errorf("expected at most 1000 distinct words, got %d", len(counts))
+	}
+	top := 0
+	for _, c := range counts {
+		top = max(top, c)
+	}
+	// the most frequent word accounts for a large share
+	if top < len(words)/10 {
+		t.Errorf("most frequent word appears only %d times", top)
+	}
+}
+
+// commonPrefix returns the length of the prefix shared by a and b.
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// averagePrefix returns the average length of the prefix shared by
+// each string with its successor in sorted order.
+func averagePrefix(data []string) float64 {
+	sorted := make([]string, len(data))
+	copy(sorted, data)
+	sort.Strings(sorted)
+	total := 0
+	for i := 1; i < len(sorted); i++ {
+		total += commonPrefix(sorted[i-1], sorted[i])
+	}
+	return float64(total) / float64(len(sorted)-1)
+}
+
+func TestURLsAndPaths(t *testing.T) {
+	urls := URLs(newRand(), 1000)
+	for _, u := range urls {
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://www.") {
+			t.Fatalf("unexpected URL %q", u)
+		}
+	}
+	if p := averagePrefix(urls); p < 20 {
+		t.Errorf("URLs share prefixes of only %.1f bytes on average", p)
+	}
+	paths := Paths(newRand(), 1000)
+	for _, p := range paths {
+		if !strings.HasPrefix(p, "/") || strings.HasSuffix(p, "/") {
+			t.Fatalf("unexpected path %q", p)
+		}
+	}
+	if p := averagePrefix(paths); p < 20 {
+		t.Errorf("paths share prefixes of only %.1f bytes on average", p)
+	}
+}
+
+func TestMultiScript(t *testing.T) {
+	widths := make(map[int]bool)
+	for _, s := range MultiScript(newRand(), 1000) {
+		if !utf8.ValidString(s) {
+			t.Fatalf("invalid UTF-8 %q", s)
+		}
+		words := strings.Split(s, " ")
+		if len(words) < 1 || len(words) > 4 {
+			t.Fatalf("unexpected number of words in %q", s)
+		}
+		for _, r := range s {
+			widths[utf8.RuneLen(r)] = true
+		}
+	}
+	for n := 1; n <= 4; n++ {
+		if !widths[n] {
+			t.Errorf("no characters encoded in %d bytes", n)
+		}
+	}
+}
+
+func TestDNAReads(t *testing.T) {
+	reads := DNAReads(newRand(), 3000)
+	checkStrings(t, "DNAReads", reads, 100, 300, "acgt")
+	// with high coverage, many reads overlap their neighbors in sorted
+	// order by far more than random sequences would
+	if p := averagePrefix(reads); p < 10 {
+		t.Errorf("reads share prefixes of only %.1f bases on average", p)
+	}
+}
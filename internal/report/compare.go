@@ -0,0 +1,225 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	gosort "sort"
+)
+
+// Load reads a report previously written in the json format.
+func Load(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := new(Report)
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return r, nil
+}
+
+// Save writes the report to the named file in the json format.
+func (r *Report) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Write(f, "json"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Delta describes the change in one result relative to the baseline.
+type Delta struct {
+	Algorithm  string
+	DataSet    string
+	Size       int
+	Base       float64 // median of the baseline, in nanoseconds
+	Current    float64 // median of the current result, in nanoseconds
+	Change     float64 // percentage change from the baseline
+	P          float64 // p-value of the Mann-Whitney U test
+	Tested     bool    // false if there were too few runs to test
+	Regression bool    // true if slower beyond the threshold
+}
+
+// Compare matches the results of the current report with those of the
+// baseline having the same algorithm, data set, and size. A result is
+// a regression if its median is more than threshold percent slower
+// than the baseline, and the difference in the run times is significant
+// at the given level. If either result has fewer than two runs, the
+// significance cannot be tested, and the threshold alone decides.
+func Compare(base, current *Report, threshold, alpha float64) []Delta {
+	type key struct {
+		algorithm, dataSet string
+		size               int
+	}
+	baseline := make(map[key]Result)
+	for _, res := range base.Results {
+		baseline[key{res.Algorithm, res.DataSet, res.Size}] = res
+	}
+	var deltas []Delta
+	for _, res := range current.Results {
+		old, ok := baseline[key{res.Algorithm, res.DataSet, res.Size}]
+		if !ok || old.Median == 0 {
+			continue
+		}
+		d := Delta{
+			Algorithm: res.Algorithm,
+			DataSet:   res.DataSet,
+			Size:      res.Size,
+			Base:      old.Median,
+			Current:   res.Median,
+			Change:    (res.Median - old.Median) / old.Median * 100,
+			P:         1,
+		}
+		if len(old.Runs) > 1 && len(res.Runs) > 1 {
+			d.P = MannWhitney(old.Runs, res.Runs)
+			d.Tested = true
+		}
+		d.Regression = d.Change > threshold && (!d.Tested || d.P < alpha)
+		deltas = append(deltas, d)
+	}
+	return deltas
+}
+
+// WriteDeltas writes a table of the deltas, marking the regressions.
+func WriteDeltas(w io.Writer, deltas []Delta) {
+	fmt.Fprintf(w, "%-12s %-14s %10s %12s %12s %9s %7s\n",
+		"algorithm", "dataset", "size", "base", "current", "change", "p")
+	for _, d := range deltas {
+		p := "n/a"
+		if d.Tested {
+			p = fmt.Sprintf("%.3f", d.P)
+		}
+		mark := ""
+		if d.Regression {
+			mark = "  REGRESSION"
+		}
+		fmt.Fprintf(w, "%-12s %-14s %10d %12.0f %12.0f %+8.1f%% %7s%s\n",
+			d.Algorithm, d.DataSet, d.Size, d.Base, d.Current, d.Change, p, mark)
+	}
+}
+
+// MannWhitney performs the two-sided Mann-Whitney U test on the two
+// samples, returning the p-value for the hypothesis that they come from
+// the same distribution. For small samples without ties the p-value is
+// exact, otherwise it uses the normal approximation, corrected for ties.
+func MannWhitney(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	// rank the combined samples, giving tied values their average rank
+	type value struct {
+		v     float64
+		fromX bool
+	}
+	all := make([]value, 0, n1+n2)
+	for _, v := range x {
+		all = append(all, value{v, true})
+	}
+	for _, v := range y {
+		all = append(all, value{v, false})
+	}
+	gosort.Slice(all, func(i, j int) bool { return all[i].v < all[j].v })
+	var rankX, tieSum float64
+	ties := false
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromX {
+				rankX += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties = true
+			tieSum += t*t*t - t
+		}
+		i = j
+	}
+	u := rankX - float64(n1*(n1+1))/2
+	u = math.Min(u, float64(n1*n2)-u)
+	if !ties && n1*n2 <= 400 {
+		p := 2 * exactUCDF(n1, n2, int(u))
+		return math.Min(p, 1)
+	}
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieSum/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	// apply a continuity correction toward the mean
+	z := (mean - u - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		return 1
+	}
+	return math.Min(math.Erfc(z/math.Sqrt2), 1)
+}
+
+// exactUCDF returns the probability that the U statistic for samples
+// of sizes n1 and n2 is at most u, when there are no ties.
+func exactUCDF(n1, n2, u int) float64 {
+	// counts[i][j][k] is the number of arrangements of i values from
+	// the first sample and j from the second with statistic k; only
+	// the current row of i is kept
+	maxU := n1 * n2
+	prev := make([][]float64, n2+1)
+	for j := range prev {
+		prev[j] = make([]float64, maxU+1)
+		prev[j][0] = 1
+	}
+	for i := 1; i <= n1; i++ {
+		curr := make([][]float64, n2+1)
+		curr[0] = make([]float64, maxU+1)
+		curr[0][0] = 1
+		for j := 1; j <= n2; j++ {
+			curr[j] = make([]float64, maxU+1)
+			for k := 0; k <= maxU; k++ {
+				// the largest value is from the first sample, which
+				// then exceeds all j values of the second sample
+				if k >= j {
+					curr[j][k] += prev[j][k-j]
+				}
+				// or the largest value is from the second sample
+				curr[j][k] += curr[j-1][k]
+			}
+		}
+		prev = curr
+	}
+	var count, total float64
+	for k, c := range prev[n2] {
+		total += c
+		if k <= u {
+			count += c
+		}
+	}
+	return count / total
+}
+
+// Regressions returns the number of deltas that are regressions.
+func Regressions(deltas []Delta) int {
+	count := 0
+	for _, d := range deltas {
+		if d.Regression {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,124 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package report
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMannWhitney(t *testing.T) {
+	tests := []struct {
+		x, y []float64
+		p    float64
+	}{
+		// completely separated samples of five, exact p = 2/252
+		{[]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2.0 / 252},
+		{[]float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 2.0 / 252},
+		// symmetrically interleaved samples show no difference
+		{[]float64{1, 4, 5, 8}, []float64{2, 3, 6, 7}, 1},
+		// U = 1 for samples of three, exact p = 2 * 2/20
+		{[]float64{1, 2, 4}, []float64{3, 5, 6}, 0.2},
+		{nil, []float64{1}, 1},
+	}
+	for _, test := range tests {
+		if p := MannWhitney(test.x, test.y); math.Abs(p-test.p) > 1e-9 {
+			t.Errorf("MannWhitney(%v, %v) = %v, expected %v", test.x, test.y, p, test.p)
+		}
+	}
+	// tied values use the normal approximation
+	p := MannWhitney([]float64{1, 1, 1, 2, 2}, []float64{3, 3, 4, 4, 4})
+	if p > 0.02 || p < 0.001 {
+		t.Errorf("unexpected p-value %v for separated samples with ties", p)
+	}
+	if p := MannWhitney([]float64{5, 5, 5}, []float64{5, 5, 5}); p != 1 {
+		t.Errorf("identical samples should have p-value 1, got %v", p)
+	}
+	// larger samples use the normal approximation
+	var x, y []float64
+	for i := 0; i < 30; i++ {
+		x = append(x, float64(i))
+		y = append(y, float64(i)+20)
+	}
+	if p := MannWhitney(x, y); p > 0.001 {
+		t.Errorf("unexpected p-value %v for shifted samples", p)
+	}
+}
+
+// runsOf converts the milliseconds into run times.
+func runsOf(ms ...int) []time.Duration {
+	runs := make([]time.Duration, len(ms))
+	for i, m := range ms {
+		runs[i] = time.Duration(m) * time.Millisecond
+	}
+	return runs
+}
+
+func TestCompare(t *testing.T) {
+	base := &Report{Results: []Result{
+		NewResult("Burst", "Random", 1000, runsOf(10, 11, 10, 12, 11), 0, 0),
+		NewResult("Merge", "Random", 1000, runsOf(10, 11, 10, 12, 11), 0, 0),
+		NewResult("Intro", "Random", 1000, runsOf(10, 11, 10, 12, 11), 0, 0),
+		NewResult("Heap", "Random", 1000, runsOf(10), 0, 0),
+	}}
+	current := &Report{Results: []Result{
+		// significantly slower
+		NewResult("Burst", "Random", 1000, runsOf(13, 14, 13, 15, 14), 0, 0),
+		// slower but not significantly
+		NewResult("Merge", "Random", 1000, runsOf(9, 20, 10, 14, 13), 0, 0),
+		// faster
+		NewResult("Intro", "Random", 1000, runsOf(8, 8, 9, 8, 9), 0, 0),
+		// untested, decided by the threshold
+		NewResult("Heap", "Random", 1000, runsOf(12), 0, 0),
+		// not in the baseline
+		NewResult("Shell", "Random", 1000, runsOf(12), 0, 0),
+	}}
+	deltas := Compare(base, current, 10, 0.05)
+	if len(deltas) != 4 {
+		t.Fatalf("expected 4 deltas, got %d", len(deltas))
+	}
+	expected := map[string]bool{"Burst": true, "Merge": false, "Intro": false, "Heap": true}
+	for _, d := range deltas {
+		if d.Regression != expected[d.Algorithm] {
+			t.Errorf("%s: regression %v, expected %v (change %.1f%%, p %.3f)",
+				d.Algorithm, d.Regression, expected[d.Algorithm], d.Change, d.P)
+		}
+	}
+	if n := Regressions(deltas); n != 2 {
+		t.Errorf("expected 2 regressions, got %d", n)
+	}
+	if deltas[3].Tested {
+		t.Error("single runs should not be tested")
+	}
+	var buf bytes.Buffer
+	WriteDeltas(&buf, deltas)
+	if strings.Count(buf.String(), "REGRESSION") != 2 {
+		t.Errorf("expected two regressions in table:\n%s", buf.String())
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	r := sampleReport()
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := r.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Results) != len(r.Results) || loaded.Results[0].Median != r.Results[0].Median {
+		t.Error("loaded report does not match saved report")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("loading missing file should fail")
+	}
+}
@@ -0,0 +1,293 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+// Package report collects and formats the results of the benchmark
+// commands as JSON or CSV, for storing and comparing results across
+// commits.
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	gosort "sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{"text", "json", "csv"}
+
+// ValidFormat returns true if the named output format is supported.
+func ValidFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Metadata describes the environment in which the benchmarks ran.
+type Metadata struct {
+	Command   string    `json:"command"`
+	Time      time.Time `json:"time"`
+	GoVersion string    `json:"go_version"`
+	GOOS      string    `json:"goos"`
+	GOARCH    string    `json:"goarch"`
+	CPU       string    `json:"cpu"`
+	NumCPU    int       `json:"num_cpu"`
+	Revision  string    `json:"revision,omitempty"`
+	Seed      int64     `json:"seed"`
+}
+
+// NewMetadata gathers the metadata for the current process, named
+// after the given command.
+func NewMetadata(command string) Metadata {
+	md := Metadata{
+		Command:   command,
+		Time:      time.Now().UTC(),
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		CPU:       cpuModel(),
+		NumCPU:    runtime.NumCPU(),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				md.Revision = setting.Value
+			}
+		}
+	}
+	return md
+}
+
+// cpuModel returns the name of the processor, if it can be determined.
+func cpuModel() string {
+	f, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if found && strings.TrimSpace(key) == "model name" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// Result holds the measurements of one algorithm sorting one data set
+// of a particular size. All times are in nanoseconds.
+type Result struct {
+	Algorithm  string    `json:"algorithm"`
+	DataSet    string    `json:"dataset"`
+	Size       int       `json:"size"`
+	Runs       []float64 `json:"runs_ns"`
+	Mean       float64   `json:"mean_ns"`
+	Median     float64   `json:"median_ns"`
+	StdDev     float64   `json:"stddev_ns"`
+	CILow      float64   `json:"ci95_low_ns"`
+	CIHigh     float64   `json:"ci95_high_ns"`
+	Outliers   int       `json:"outliers"`
+	Iterations int       `json:"iterations,omitempty"`
+	Allocs     int64     `json:"allocs_per_run"`
+	Bytes      int64     `json:"bytes_per_run"`
+	PeakHeap   int64     `json:"peak_heap_bytes"`
+}
+
+// NewResult constructs a Result from the run times, computing the
+// summary statistics.
+func NewResult(algorithm, dataSet string, size int, runs []time.Duration, allocs, bytes int64) Result {
+	r := Result{
+		Algorithm: algorithm,
+		DataSet:   dataSet,
+		Size:      size,
+		Runs:      make([]float64, len(runs)),
+		Allocs:    allocs,
+		Bytes:     bytes,
+	}
+	for i, d := range runs {
+		r.Runs[i] = float64(d.Nanoseconds())
+	}
+	r.Mean, r.Median, r.StdDev = Summarize(r.Runs)
+	r.CILow, r.CIHigh = ConfidenceInterval(r.Runs)
+	r.Outliers = Outliers(r.Runs)
+	return r
+}
+
+// Margin returns the half-width of the confidence interval of the mean
+// as a percentage of the mean, or zero if the mean is zero.
+func (r Result) Margin() float64 {
+	if r.Mean == 0 {
+		return 0
+	}
+	return (r.CIHigh - r.CILow) / 2 / r.Mean * 100
+}
+
+// Summarize computes the mean, median, and sample standard deviation
+// of the values, all of which are zero if there are no values.
+func Summarize(values []float64) (mean, median, stddev float64) {
+	n := len(values)
+	if n == 0 {
+		return 0, 0, 0
+	}
+	sorted := make([]float64, n)
+	copy(sorted, values)
+	gosort.Float64s(sorted)
+	if n%2 == 1 {
+		median = sorted[n/2]
+	} else {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(n)
+	if n > 1 {
+		var sum float64
+		for _, v := range values {
+			sum += (v - mean) * (v - mean)
+		}
+		stddev = math.Sqrt(sum / float64(n-1))
+	}
+	return
+}
+
+// tQuantiles holds the 97.5th percentile of Student's t distribution
+// for 1 through 30 degrees of freedom.
+var tQuantiles = []float64{12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365,
+	2.306, 2.262, 2.228, 2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110,
+	2.101, 2.093, 2.086, 2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052,
+	2.048, 2.045, 2.042}
+
+// ConfidenceInterval returns the bounds of the 95% confidence interval
+// for the mean of the values, based on Student's t distribution. With
+// fewer than two values, both bounds are equal to the mean.
+func ConfidenceInterval(values []float64) (low, high float64) {
+	mean, _, stddev := Summarize(values)
+	n := len(values)
+	if n < 2 {
+		return mean, mean
+	}
+	t := 1.96
+	if n-1 <= len(tQuantiles) {
+		t = tQuantiles[n-2]
+	}
+	margin := t * stddev / math.Sqrt(float64(n))
+	return mean - margin, mean + margin
+}
+
+// quantile returns the q-th quantile of the sorted values, interpolating
+// linearly between the nearest values.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// Outliers returns the number of values lying more than 1.5 times the
+// interquartile range below the first or above the third quartile.
+func Outliers(values []float64) int {
+	if len(values) < 4 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	gosort.Float64s(sorted)
+	q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+	fence := 1.5 * (q3 - q1)
+	count := 0
+	for _, v := range sorted {
+		if v < q1-fence || v > q3+fence {
+			count++
+		}
+	}
+	return count
+}
+
+// Report is the complete set of results from one benchmark command.
+type Report struct {
+	Metadata Metadata `json:"metadata"`
+	Results  []Result `json:"results"`
+}
+
+// Write formats the report to the writer in the named format, either
+// json or csv; the commands write text output as the results arrive.
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case "csv":
+		return r.writeCSV(w)
+	}
+	return fmt.Errorf("unsupported output format '%s'", format)
+}
+
+// csvHeader names the columns of the CSV output.
+var csvHeader = []string{"algorithm", "dataset", "size", "runs_ns", "mean_ns",
+	"median_ns", "stddev_ns", "ci95_low_ns", "ci95_high_ns", "outliers",
+	"iterations", "allocs_per_run", "bytes_per_run", "peak_heap_bytes",
+	"go_version", "goos", "goarch", "cpu", "num_cpu", "revision", "seed", "time"}
+
+// writeCSV writes one row per result, with the run times separated by
+// semicolons, and the metadata repeated on every row.
+func (r *Report) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write(csvHeader)
+	md := r.Metadata
+	for _, res := range r.Results {
+		runs := make([]string, len(res.Runs))
+		for i, v := range res.Runs {
+			runs[i] = formatFloat(v)
+		}
+		cw.Write([]string{res.Algorithm, res.DataSet, strconv.Itoa(res.Size),
+			strings.Join(runs, ";"), formatFloat(res.Mean), formatFloat(res.Median),
+			formatFloat(res.StdDev), formatFloat(res.CILow), formatFloat(res.CIHigh),
+			strconv.Itoa(res.Outliers), strconv.Itoa(res.Iterations),
+			strconv.FormatInt(res.Allocs, 10), strconv.FormatInt(res.Bytes, 10),
+			strconv.FormatInt(res.PeakHeap, 10), md.GoVersion, md.GOOS, md.GOARCH, md.CPU, strconv.Itoa(md.NumCPU), md.Revision,
+			strconv.FormatInt(md.Seed, 10), md.Time.Format(time.RFC3339)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// FormatBytes formats the number of bytes for display, in the largest
+// binary unit in which it is at least one.
+func FormatBytes(n int64) string {
+	const units = "KMGTPE"
+	if n < 1024 && n > -1024 {
+		return fmt.Sprintf("%dB", n)
+	}
+	v := float64(n) / 1024
+	i := 0
+	for (v >= 1024 || v <= -1024) && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f%ciB", v, units[i])
+}
+
+// formatFloat formats the value with no more precision than needed.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
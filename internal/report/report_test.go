@@ -0,0 +1,162 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	mean, median, stddev := Summarize(nil)
+	if mean != 0 || median != 0 || stddev != 0 {
+		t.Error("summary of no values should be zero")
+	}
+	mean, median, stddev = Summarize([]float64{4})
+	if mean != 4 || median != 4 || stddev != 0 {
+		t.Errorf("unexpected summary of one value: %v %v %v", mean, median, stddev)
+	}
+	mean, median, stddev = Summarize([]float64{9, 1, 5, 3})
+	if mean != 4.5 || median != 4 {
+		t.Errorf("unexpected mean %v or median %v", mean, median)
+	}
+	if math.Abs(stddev-3.4157) > 0.0001 {
+		t.Errorf("unexpected standard deviation %v", stddev)
+	}
+}
+
+func TestConfidenceInterval(t *testing.T) {
+	low, high := ConfidenceInterval([]float64{7})
+	if low != 7 || high != 7 {
+		t.Errorf("interval of one value should be the value, got %v..%v", low, high)
+	}
+	// mean 4.5, standard deviation 3.4157, t = 3.182 for 3 degrees
+	low, high = ConfidenceInterval([]float64{9, 1, 5, 3})
+	if math.Abs(low-(-0.9344)) > 0.001 || math.Abs(high-9.9344) > 0.001 {
+		t.Errorf("unexpected interval %v..%v", low, high)
+	}
+	// large samples use the normal distribution
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i % 2)
+	}
+	low, high = ConfidenceInterval(values)
+	if math.Abs(high-low-2*1.96*0.50252/10) > 0.001 {
+		t.Errorf("unexpected interval %v..%v", low, high)
+	}
+}
+
+func TestOutliers(t *testing.T) {
+	tests := []struct {
+		values []float64
+		count  int
+	}{
+		{nil, 0},
+		{[]float64{1, 100, 1}, 0},
+		{[]float64{10, 11, 12, 10, 11, 12, 10, 11}, 0},
+		{[]float64{10, 11, 12, 10, 11, 12, 10, 50}, 1},
+		{[]float64{1, 10, 11, 12, 10, 11, 12, 10, 50}, 2},
+	}
+	for _, test := range tests {
+		if n := Outliers(test.values); n != test.count {
+			t.Errorf("Outliers(%v) = %d, expected %d", test.values, n, test.count)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n        int64
+		expected string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{1024, "1.0KiB"},
+		{1536, "1.5KiB"},
+		{5 << 20, "5.0MiB"},
+		{3 << 30, "3.0GiB"},
+	}
+	for _, test := range tests {
+		if s := FormatBytes(test.n); s != test.expected {
+			t.Errorf("FormatBytes(%d) = %q, expected %q", test.n, s, test.expected)
+		}
+	}
+}
+
+// sampleReport returns a report with a couple of results.
+func sampleReport() *Report {
+	runs := []time.Duration{3 * time.Millisecond, time.Millisecond, 2 * time.Millisecond}
+	md := NewMetadata("test")
+	md.Seed = 42
+	return &Report{
+		Metadata: md,
+		Results: []Result{
+			NewResult("Merge", "Random", 1000, runs, 12, 4096),
+			NewResult("Burst, fast", "Random", 1000, runs[:1], 0, 0),
+		},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	r := sampleReport()
+	var buf bytes.Buffer
+	if err := r.Write(&buf, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Metadata.GoVersion == "" || decoded.Metadata.NumCPU < 1 || decoded.Metadata.Seed != 42 {
+		t.Error("metadata missing from JSON output")
+	}
+	if len(decoded.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(decoded.Results))
+	}
+	res := decoded.Results[0]
+	if res.Algorithm != "Merge" || res.Size != 1000 || len(res.Runs) != 3 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if res.Median != 2e6 || res.Mean != 2e6 || res.Allocs != 12 || res.Bytes != 4096 {
+		t.Errorf("unexpected statistics %+v", res)
+	}
+	// t = 4.303 for 2 degrees of freedom, standard deviation 1ms
+	if math.Abs(res.Margin()-4.303/math.Sqrt(3)/2*100) > 0.01 {
+		t.Errorf("unexpected margin %v", res.Margin())
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	r := sampleReport()
+	var buf bytes.Buffer
+	if err := r.Write(&buf, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "algorithm" || rows[2][0] != "Burst, fast" {
+		t.Errorf("unexpected CSV rows %q", rows)
+	}
+	if rows[1][3] != "3000000;1000000;2000000" {
+		t.Errorf("unexpected run times %q", rows[1][3])
+	}
+	if seed := rows[1][len(rows[1])-2]; seed != "42" {
+		t.Errorf("expected seed 42, got %q", seed)
+	}
+	if err := r.Write(&buf, "xml"); err == nil {
+		t.Error("unsupported format should return an error")
+	}
+}
@@ -4,10 +4,228 @@
 // license that can be found in the LICENSE file.
 //
 
+// Command sortingo sorts the lines of the named files, or of the
+// standard input, using one of the algorithms of the sort package, and
+// writes the result to the standard output. Lines are compared byte by
+// byte, so the output matches that of 'LC_ALL=C sort'.
 package main
 
-import "fmt"
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	gosort "sort"
+	"strings"
 
+	"github.com/nlfiedler/sortingo/sort"
+)
+
+// algorithm describes one of the sorts that may be selected.
+type algorithm struct {
+	name      string         // name given to the -a flag
+	sort      func([]string) // sorts the lines in place
+	quadratic bool           // true if impractical for large inputs
+}
+
+// algorithms lists the sorts in the order in which they are listed,
+// named as in the sortbench command.
+var algorithms = []algorithm{
+	{"Binsert", sort.BinaryInsertionSort, true},
+	{"Burst", sort.BurstSort, false},
+	{"Comb", sort.CombSort, false},
+	{"2PivotQ", sort.DualPivotQuickSort, false},
+	{"Gnome", sort.GnomeSort, true},
+	{"Heap", sort.HeapSort, false},
+	{"HybridComb", sort.HybridCombSort, false},
+	{"Insert", sort.InsertionSort, true},
+	{"Intro", sort.IntroSort, false},
+	{"Merge", sort.MergeSort, false},
+	{"MkQ", sort.MultikeyQuickSort, false},
+	{"Quick", gosort.Strings, false},
+	{"Select", sort.SelectionSort, true},
+	{"Shell", sort.ShellSort, false},
+}
+
+// defaultAlgorithm is the sort used when none is selected.
+const defaultAlgorithm = "Burst"
+
+// findAlgorithm returns the sort with the given name, ignoring case and
+// any "Sort" suffix, such that "burst" and "BurstSort" both select the
+// burstsort.
+func findAlgorithm(name string) (algorithm, bool) {
+	name = strings.TrimSuffix(strings.ToLower(name), "sort")
+	for _, alg := range algorithms {
+		if strings.ToLower(alg.name) == name {
+			return alg, true
+		}
+	}
+	return algorithm{}, false
+}
+
+// usage displays the usage information.
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: sortingo [options] [file ...]")
+	fmt.Fprintln(os.Stderr, "\tSorts the lines of the files, or of the standard input if no files")
+	fmt.Fprintln(os.Stderr, "\tare named or a file is named '-'. Lines are compared byte by byte,")
+	fmt.Fprintln(os.Stderr, "\tas by 'LC_ALL=C sort'. Options are given separately, as in '-r -u',")
+	fmt.Fprintln(os.Stderr, "\tand before the files.")
+	fmt.Fprintln(os.Stderr, "\t-a, -algorithm <name>")
+	fmt.Fprintf(os.Stderr, "\t\tSelect the sorting algorithm; the default is %s. See -list\n", defaultAlgorithm)
+	fmt.Fprintln(os.Stderr, "\t\tfor the names.")
+	fmt.Fprintln(os.Stderr, "\t-list")
+	fmt.Fprintln(os.Stderr, "\t\tDisplay a list of the sorting algorithms.")
+	fmt.Fprintln(os.Stderr, "\t-o <path>")
+	fmt.Fprintln(os.Stderr, "\t\tWrite the result to the file instead of the standard output.")
+	fmt.Fprintln(os.Stderr, "\t\tThe input is read in full first, so the file may also be one")
+	fmt.Fprintln(os.Stderr, "\t\tof the inputs.")
+	fmt.Fprintln(os.Stderr, "\t-r")
+	fmt.Fprintln(os.Stderr, "\t\tReverse the order of the output.")
+	fmt.Fprintln(os.Stderr, "\t-u")
+	fmt.Fprintln(os.Stderr, "\t\tOutput only the first of each run of identical lines.")
+	fmt.Fprintln(os.Stderr, "\t-z")
+	fmt.Fprintln(os.Stderr, "\t\tLines end with a null character rather than a newline. Since")
+	fmt.Fprintln(os.Stderr, "\t\tthe sorts treat the null character as the end of a string,")
+	fmt.Fprintln(os.Stderr, "\t\tinput containing nulls must be sorted with this option.")
+}
+
+// list displays the names of the sorting algorithms.
+func list() {
+	for _, alg := range algorithms {
+		note := ""
+		if alg.name == defaultAlgorithm {
+			note = " (default)"
+		} else if alg.quadratic {
+			note = " (quadratic, slow on large inputs)"
+		}
+		fmt.Printf("%s%s\n", alg.name, note)
+	}
+}
+
+// readLines reads the lines of all the inputs, in order, each ending
+// with the separator. The last line of an input need not end with the
+// separator. The lines share a single backing string.
+func readLines(inputs []io.Reader, sep byte) ([]string, error) {
+	var b strings.Builder
+	for _, r := range inputs {
+		start := b.Len()
+		if _, err := io.Copy(&b, r); err != nil {
+			return nil, err
+		}
+		if text := b.String(); len(text) > start && text[len(text)-1] != sep {
+			b.WriteByte(sep)
+		}
+	}
+	text := b.String()
+	if text == "" {
+		return []string{}, nil
+	}
+	return strings.Split(text[:len(text)-1], string(sep)), nil
+}
+
+// sortLines sorts the lines with the given sort, then reverses them or
+// removes the duplicates as requested, returning the result.
+func sortLines(lines []string, sorter func([]string), reverse, unique bool) []string {
+	sorter(lines)
+	if unique {
+		lines = slices.Compact(lines)
+	}
+	if reverse {
+		slices.Reverse(lines)
+	}
+	return lines
+}
+
+// writeLines writes each of the lines followed by the separator.
+func writeLines(w io.Writer, lines []string, sep byte) error {
+	bw := bufio.NewWriterSize(w, 1<<16)
+	for _, line := range lines {
+		bw.WriteString(line)
+		bw.WriteByte(sep)
+	}
+	return bw.Flush()
+}
+
+// fail reports the error and exits with the status used by sort(1)
+// for trouble.
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "sortingo: %v\n", err)
+	os.Exit(2)
+}
+
+// main sorts the lines of the inputs named on the command line.
 func main() {
-	fmt.Printf("Placeholder for go tool\n")
+	var name string
+	flag.StringVar(&name, "a", defaultAlgorithm, "sorting algorithm")
+	flag.StringVar(&name, "algorithm", defaultAlgorithm, "sorting algorithm")
+	var listFlag = flag.Bool("list", false, "list the sorting algorithms")
+	var output = flag.String("o", "", "file to which the output is written")
+	var reverse = flag.Bool("r", false, "reverse the order of the output")
+	var unique = flag.Bool("u", false, "output only the first of identical lines")
+	var nulls = flag.Bool("z", false, "lines end with a null instead of a newline")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *listFlag {
+		list()
+		os.Exit(0)
+	}
+
+	alg, ok := findAlgorithm(name)
+	if !ok {
+		fail(fmt.Errorf("unknown algorithm '%s', see -list for the names", name))
+	}
+	sep := byte('\n')
+	if *nulls {
+		sep = 0
+	}
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	inputs := make([]io.Reader, len(paths))
+	for i, path := range paths {
+		if path == "-" {
+			inputs[i] = os.Stdin
+			continue
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		inputs[i] = f
+	}
+	lines, err := readLines(inputs, sep)
+	if err != nil {
+		fail(err)
+	}
+	if !*nulls {
+		for i, line := range lines {
+			if strings.IndexByte(line, 0) >= 0 {
+				fail(fmt.Errorf("line %d contains a null character, use -z for null-terminated lines", i+1))
+			}
+		}
+	}
+	lines = sortLines(lines, alg.sort, *reverse, *unique)
+
+	if *output == "" {
+		if err := writeLines(os.Stdout, lines, sep); err != nil {
+			fail(err)
+		}
+		return
+	}
+	out, err := os.Create(*output)
+	if err != nil {
+		fail(err)
+	}
+	if err := writeLines(out, lines, sep); err != nil {
+		fail(err)
+	}
+	if err := out.Close(); err != nil {
+		fail(err)
+	}
 }
@@ -0,0 +1,91 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindAlgorithm(t *testing.T) {
+	for _, name := range []string{"Burst", "burst", "BurstSort", "BURSTSORT"} {
+		if alg, ok := findAlgorithm(name); !ok || alg.name != "Burst" {
+			t.Errorf("expected %s to select Burst, got %q", name, alg.name)
+		}
+	}
+	if alg, ok := findAlgorithm("2pivotq"); !ok || alg.name != "2PivotQ" {
+		t.Errorf("expected 2PivotQ, got %q", alg.name)
+	}
+	for _, name := range []string{"", "sort", "bogo"} {
+		if _, ok := findAlgorithm(name); ok {
+			t.Errorf("expected %q to be unknown", name)
+		}
+	}
+	if _, ok := findAlgorithm(defaultAlgorithm); !ok {
+		t.Error("default algorithm is not listed")
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	inputs := []io.Reader{
+		strings.NewReader("b\na\n"),
+		strings.NewReader(""),
+		strings.NewReader("d\r\n\nc"),
+		strings.NewReader("e"),
+	}
+	lines, err := readLines(inputs, '\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the last line of each input ends even without a newline
+	expected := []string{"b", "a", "d\r", "", "c", "e"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("expected %q, got %q", expected, lines)
+	}
+	lines, err = readLines([]io.Reader{strings.NewReader("x\ny\x00z\x00")}, 0)
+	if err != nil || !reflect.DeepEqual(lines, []string{"x\ny", "z"}) {
+		t.Errorf("unexpected null-terminated lines %q (%v)", lines, err)
+	}
+	lines, err = readLines([]io.Reader{strings.NewReader("")}, '\n')
+	if err != nil || len(lines) != 0 {
+		t.Errorf("expected no lines, got %q (%v)", lines, err)
+	}
+}
+
+func TestSortLines(t *testing.T) {
+	input := strings.Fields("pear apple fig apple banana fig apple")
+	for _, alg := range algorithms {
+		lines := sortLines(append([]string(nil), input...), alg.sort, false, false)
+		expected := strings.Fields("apple apple apple banana fig fig pear")
+		if !reflect.DeepEqual(lines, expected) {
+			t.Errorf("%s: expected %q, got %q", alg.name, expected, lines)
+		}
+		lines = sortLines(append([]string(nil), input...), alg.sort, true, true)
+		expected = strings.Fields("pear fig banana apple")
+		if !reflect.DeepEqual(lines, expected) {
+			t.Errorf("%s: expected %q reversed and unique, got %q", alg.name, expected, lines)
+		}
+	}
+}
+
+func TestWriteLines(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeLines(&buf, []string{"a", "", "b"}, '\n'); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "a\n\nb\n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+	buf.Reset()
+	writeLines(&buf, []string{"a", "b"}, 0)
+	if buf.String() != "a\x00b\x00" {
+		t.Errorf("unexpected null-terminated output %q", buf.String())
+	}
+}
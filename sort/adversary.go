@@ -0,0 +1,100 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// Construction of worst-case inputs for comparison sorts, using the
+// adversary described by M. D. McIlroy in "A Killer Adversary for
+// Quicksort" (Software: Practice and Experience, 1999). The adversary
+// watches the comparisons made by the sort, and decides the values of
+// the elements only as late as possible. Each element starts out as
+// "gas", which compares greater than every decided ("solid") value.
+// When two gas elements are compared, one of them is frozen to the
+// next solid value, favoring the element that appears to be the pivot.
+// Since the values are consistent with every comparison that was made,
+// sorting the resulting input follows the same course as before.
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// adversary holds the state of the comparisons made so far.
+type adversary struct {
+	values    []int // value of each element, or gas if undecided
+	gas       int   // value of the undecided elements
+	nsolid    int   // number of elements with decided values
+	candidate int   // element most likely to be the pivot
+}
+
+// freeze assigns the next solid value to the element.
+func (adv *adversary) freeze(x int) {
+	adv.values[x] = adv.nsolid
+	adv.nsolid++
+}
+
+// less compares the two elements, deciding the value of one of them if
+// both are undecided.
+func (adv *adversary) less(x, y int) bool {
+	if adv.values[x] == adv.gas && adv.values[y] == adv.gas {
+		if x == adv.candidate {
+			adv.freeze(x)
+		} else {
+			adv.freeze(y)
+		}
+	}
+	if adv.values[x] == adv.gas {
+		adv.candidate = x
+	} else if adv.values[y] == adv.gas {
+		adv.candidate = y
+	}
+	return adv.values[x] < adv.values[y]
+}
+
+// Adversary constructs an input of n distinct values, from 0 to n-1,
+// that provokes the worst behavior from the given sort. The sort is
+// run once on the numbers 0 through n-1, and must compare elements
+// only by calling less. Each comparison sort in this package that is
+// practical for large inputs has a Func variant that qualifies, such as
+// IntroSortFunc[int] or MergeSortFunc[int], making the same comparisons
+// as the sort of strings; the insertion, binary insertion, gnome and
+// selection sorts are quadratic on ordinary input and have none. For a
+// deterministic sort, sorting the returned values makes the same
+// comparisons as during their construction.
+func Adversary(n int, sorter func(data []int, less func(x, y int) bool)) []int {
+	adv := &adversary{
+		values:    make([]int, n),
+		gas:       n,
+		candidate: -1,
+	}
+	data := make([]int, n)
+	for i := range data {
+		data[i] = i
+		adv.values[i] = adv.gas
+	}
+	sorter(data, adv.less)
+	// elements never compared to another undecided element can take
+	// any of the remaining values
+	for i, v := range adv.values {
+		if v == adv.gas {
+			adv.freeze(i)
+		}
+	}
+	return adv.values
+}
+
+// AdversaryStrings is like Adversary, but returns the values as strings
+// of decimal digits, zero-padded to equal length such that they sort in
+// the same order as the numbers.
+func AdversaryStrings(n int, sorter func(data []int, less func(x, y int) bool)) []string {
+	values := Adversary(n, sorter)
+	width := len(strconv.Itoa(iMax(n-1, 0)))
+	result := make([]string, n)
+	for i, v := range values {
+		result[i] = fmt.Sprintf("%0*d", width, v)
+	}
+	return result
+}
@@ -0,0 +1,206 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// countingSorter returns a sorter suitable for Adversary, which wraps
+// the given sort to count the comparisons made.
+func countingSorter(f func([]int, func(x, y int) bool), count *int) func([]int, func(x, y int) bool) {
+	return func(data []int, less func(x, y int) bool) {
+		f(data, func(x, y int) bool {
+			*count++
+			return less(x, y)
+		})
+	}
+}
+
+// comparisons returns the number of comparisons the sort makes when
+// sorting a copy of the data.
+func comparisons(f func([]int, func(x, y int) bool), data []int) int {
+	count := 0
+	input := make([]int, len(data))
+	copy(input, data)
+	f(input, func(x, y int) bool {
+		count++
+		return x < y
+	})
+	if !sort.IntsAreSorted(input) {
+		panic("adversarial input not sorted")
+	}
+	return count
+}
+
+// sameMoves reports whether the sort of strings rearranges them exactly
+// as its Func variant does. Equal strings are told apart by their
+// backing arrays, so the input should contain duplicates held
+// separately.
+func sameMoves(f func([]string), g func([]string, func(x, y string) bool), input []string) bool {
+	a := make([]string, len(input))
+	copy(a, input)
+	b := make([]string, len(input))
+	copy(b, input)
+	f(a)
+	g(b, stringLess)
+	for i := range a {
+		if unsafe.StringData(a[i]) != unsafe.StringData(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// pairedStrings returns the values as strings, with each pair of
+// values made equal, but held separately, for use with sameMoves.
+func pairedStrings(values []int) []string {
+	pairs := make([]string, len(values))
+	for i, v := range values {
+		pairs[i] = fmt.Sprintf("%08d", v/2)
+	}
+	return pairs
+}
+
+// separateWords returns the first n of the non-unique words, with
+// each held separately, for use with sameMoves.
+func separateWords(n int) []string {
+	words := make([]string, n)
+	for i, s := range nonUniqueWords[:n] {
+		words[i] = strings.Clone(s)
+	}
+	return words
+}
+
+// nlogn returns n times the base 2 logarithm of n.
+func nlogn(n int) float64 {
+	return float64(n) * math.Log2(float64(n))
+}
+
+func TestAdversaryArguments(t *testing.T) {
+	if r := Adversary(0, IntroSortFunc[int]); len(r) != 0 {
+		t.Error("adversary of zero elements should be empty")
+	}
+	if r := AdversaryStrings(1, IntroSortFunc[int]); len(r) != 1 || r[0] != "0" {
+		t.Errorf("adversary of one element should be [0], got %v", r)
+	}
+	// the values are a permutation of 0 to n-1, and the strings are
+	// in the same order as the numbers
+	n := 1000
+	values := Adversary(n, DualPivotQuickSortFunc[int])
+	strs := AdversaryStrings(n, DualPivotQuickSortFunc[int])
+	seen := make([]bool, n)
+	for i, v := range values {
+		if v < 0 || v >= n || seen[v] {
+			t.Fatalf("invalid adversary value %d", v)
+		}
+		seen[v] = true
+		if i > 0 && (values[i-1] < v) != (strs[i-1] < strs[i]) {
+			t.Fatalf("strings %q and %q out of order with values", strs[i-1], strs[i])
+		}
+	}
+}
+
+func TestAdversaryReplay(t *testing.T) {
+	// sorting the adversarial input makes the same comparisons as were
+	// made during its construction
+	for _, f := range []func([]int, func(x, y int) bool){
+		IntroSortFunc[int], DualPivotQuickSortFunc[int], HeapSortFunc[int],
+		ShellSortFunc[int], MergeSortFunc[int], CombSortFunc[int], HybridCombSortFunc[int],
+	} {
+		count := 0
+		values := Adversary(2000, countingSorter(f, &count))
+		if c := comparisons(f, values); c != count {
+			t.Errorf("replay made %d comparisons, expected %d", c, count)
+		}
+	}
+}
+
+func TestAdversaryIntroSort(t *testing.T) {
+	for _, n := range []int{smallDataSize, mediumDataSize, largeDataSize} {
+		values := Adversary(n, IntroSortFunc[int])
+		// the heap sort fallback bounds the comparisons, while random
+		// input takes a bit more than n log n comparisons
+		count := comparisons(IntroSortFunc[int], values)
+		if bound := 5 * nlogn(n); float64(count) > bound {
+			t.Errorf("IntroSort made %d comparisons on %d elements, expected at most %.0f", count, n, bound)
+		}
+		input := AdversaryStrings(n, IntroSortFunc[int])
+		IntroSort(input)
+		if !sort.StringsAreSorted(input) {
+			t.Errorf("adversarial input of %d elements not sorted", n)
+		}
+		// IntroSort is a copy of IntroSortFunc specialized for strings,
+		// so the bound holds for it only while the two make the same
+		// moves; pairing up the values makes any difference visible
+		if !sameMoves(IntroSort, IntroSortFunc[string], pairedStrings(values)) {
+			t.Errorf("IntroSort and IntroSortFunc differ on %d elements", n)
+		}
+	}
+	if !sameMoves(IntroSort, IntroSortFunc[string], separateWords(mediumDataSize)) {
+		t.Error("IntroSort and IntroSortFunc differ on non-unique words")
+	}
+}
+
+func TestAdversaryDualPivotQuickSort(t *testing.T) {
+	// The adversary drives this sort to quadratic behavior, which serves
+	// to demonstrate the effectiveness of the adversary, and to ensure
+	// the sort still produces correct results.
+	n := mediumDataSize / 4
+	values := Adversary(n, DualPivotQuickSortFunc[int])
+	count := comparisons(DualPivotQuickSortFunc[int], values)
+	if float64(count) < 10*nlogn(n) {
+		t.Errorf("adversary provoked only %d comparisons on %d elements", count, n)
+	}
+	input := AdversaryStrings(n, DualPivotQuickSortFunc[int])
+	DualPivotQuickSort(input)
+	if !sort.StringsAreSorted(input) {
+		t.Error("adversarial input not sorted")
+	}
+}
+
+func TestAdversaryFunc(t *testing.T) {
+	// Each sort of strings must make the same moves as its Func variant,
+	// on which the adversary is run, and sort the adversarial input. The
+	// heap and merge sorts are bounded by n log n comparisons in the
+	// worst case, with a constant of about 2 and 1 respectively.
+	sorts := []struct {
+		name  string
+		f     func([]string)
+		g     func([]string, func(x, y string) bool)
+		h     func([]int, func(x, y int) bool)
+		bound float64
+	}{
+		{"HeapSort", HeapSort, HeapSortFunc[string], HeapSortFunc[int], 2},
+		{"ShellSort", ShellSort, ShellSortFunc[string], ShellSortFunc[int], 0},
+		{"MergeSort", MergeSort, MergeSortFunc[string], MergeSortFunc[int], 1},
+		{"CombSort", CombSort, CombSortFunc[string], CombSortFunc[int], 0},
+		{"HybridCombSort", HybridCombSort, HybridCombSortFunc[string], HybridCombSortFunc[int], 0},
+	}
+	n := mediumDataSize
+	words := separateWords(n)
+	for _, s := range sorts {
+		values := Adversary(n, s.h)
+		count := comparisons(s.h, values)
+		if s.bound > 0 && float64(count) > s.bound*nlogn(n) {
+			t.Errorf("%s made %d comparisons on %d elements, expected at most %.0f", s.name, count, n, s.bound*nlogn(n))
+		}
+		input := AdversaryStrings(n, s.h)
+		s.f(input)
+		if !sort.StringsAreSorted(input) {
+			t.Errorf("%s: adversarial input not sorted", s.name)
+		}
+		if !sameMoves(s.f, s.g, pairedStrings(values)) || !sameMoves(s.f, s.g, words) {
+			t.Errorf("%s and its Func variant differ", s.name)
+		}
+	}
+}
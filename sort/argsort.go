@@ -0,0 +1,390 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// This file contains the "argsort" variants of the sorting algorithms,
+// which leave the input untouched and instead return the permutation
+// of indices that would put the input into sorted order. The resulting
+// permutation may be applied to any number of parallel slices using
+// ApplyPermutation.
+
+import (
+	"math"
+	"reflect"
+)
+
+// identity returns a new slice of indices from 0 to n-1.
+func identity(n int) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
+
+// ApplyPermutation rearranges each of the given slices in place such
+// that element i of each slice becomes the element formerly at perm[i].
+// That is, applying the result of BurstSortIndex(a) to a leaves a in
+// sorted order. Each slice must have the same length as perm, otherwise
+// this function panics, as it does if perm is not a valid permutation.
+func ApplyPermutation(perm []int, slices ...interface{}) {
+	n := len(perm)
+	swappers := make([]func(i, j int), len(slices))
+	for i, s := range slices {
+		if reflect.ValueOf(s).Len() != n {
+			panic("sort: slice length does not match permutation")
+		}
+		swappers[i] = reflect.Swapper(s)
+	}
+	visited := make([]bool, n)
+	for i := 0; i < n; i++ {
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+		// Follow the cycle starting at i, pulling each element into
+		// place and carrying the displaced element along the cycle.
+		j := i
+		for {
+			k := perm[j]
+			if k == i {
+				break
+			}
+			if k < 0 || k >= n || visited[k] {
+				panic("sort: invalid permutation")
+			}
+			for _, swap := range swappers {
+				swap(j, k)
+			}
+			visited[k] = true
+			j = k
+		}
+	}
+}
+
+// BurstSortIndex returns the permutation of indices that sorts the
+// given strings, as determined by the burstsort algorithm. The input
+// slice is not modified. The relative order of the indices of equal
+// strings is unspecified.
+func BurstSortIndex(strings []string) []int {
+	idx := identity(len(strings))
+	if len(strings) > 1 {
+		root := new(burstNode)
+		burstInsertIndex(root, strings)
+		burstTraverseIndex(root, strings, idx, 0, 0)
+	}
+	return idx
+}
+
+// burstInsertIndex is like burstInsert except that the buckets hold
+// the indices of the strings rather than the strings themselves.
+func burstInsertIndex(root *burstNode, strings []string) {
+	for i, word := range strings {
+		curr, c, p := burstLocate(root, word)
+		curr.add(c, i)
+
+		// is bucket size above the threshold?
+		for curr.size(c) >= threshold && c != nullterm {
+			p++
+			newt := new(burstNode)
+			var cc uint8 = nullterm
+			ptrs := curr.get(c).(bucket)
+			size := curr.size(c)
+			for j := 0; j < size; j++ {
+				k := ptrs[j].(int)
+				cc = charAt(strings[k], p)
+				newt.add(cc, k)
+			}
+			curr.set(c, newt)
+			curr = newt
+			c = cc
+		}
+	}
+}
+
+// burstTraverseIndex is like burstTraverse except that it writes the
+// indices held in the trie to idx, sorting each bucket by the strings
+// to which the indices refer.
+func burstTraverseIndex(node *burstNode, strings []string, idx []int, pos, depth int) int {
+	for c := 0; c < alphabetSize; c++ {
+		ch := uint8(c)
+		count := node.size(ch)
+		if count < 0 {
+			pos = burstTraverseIndex(node.get(ch).(*burstNode), strings, idx, pos, depth+1)
+		} else if count > 0 {
+			off := pos
+			if c == 0 {
+				// Visit all of the daisy-chained null buckets.
+				for b := range node.nullBuckets() {
+					for _, v := range b {
+						idx[off] = v.(int)
+						off++
+					}
+				}
+			} else {
+				b := node.get(ch).(bucket)
+				dst := idx[off : off+count]
+				for i, v := range b {
+					dst[i] = v.(int)
+				}
+				if count > 1 {
+					multikeyQuickSortIndex(strings, dst, depth+1)
+				}
+			}
+			pos += count
+		}
+	}
+	return pos
+}
+
+// MultikeyQuickSortIndex returns the permutation of indices that sorts
+// the given strings, as determined by the multikey quicksort algorithm.
+// The input slice is not modified. The relative order of the indices of
+// equal strings is unspecified.
+func MultikeyQuickSortIndex(a []string) []int {
+	idx := identity(len(a))
+	multikeyQuickSortIndex(a, idx, 0)
+	return idx
+}
+
+// multikeyQuickSortIndex is identical to MultikeyQuickSortDepth except
+// that it rearranges the indices in idx, which refer to the strings in
+// keys, rather than the strings themselves.
+func multikeyQuickSortIndex(keys []string, idx []int, depth int) {
+	n := len(idx)
+	if n < insertionThreshold {
+		insertionSortIndexDepth(keys, idx, depth)
+		return
+	}
+
+	// Find the median of three to determine our pivot value.
+	pl := 0
+	pm := n / 2
+	pn := n - 1
+	var r int
+	if n > 30 {
+		// On larger slices, find a pseudo median of nine elements.
+		d := n / 8
+		pl = med3Index(keys, idx, 0, d, 2*d, depth)
+		pm = med3Index(keys, idx, n/2-d, pm, n/2+d, depth)
+		pn = med3Index(keys, idx, n-1-2*d, n-1-d, pn, depth)
+	}
+	pm = med3Index(keys, idx, pl, pm, pn, depth)
+
+	// Move the pivot to the start of the slice.
+	idx[0], idx[pm] = idx[pm], idx[0]
+
+	v := int(charAt(keys[idx[0]], depth))
+	allzeros := v == 0
+	le, lt := 1, 1
+	gt := n - 1
+	ge := gt
+	for {
+		// Move elements smaller than pivot to the left.
+		for ; lt <= gt; lt++ {
+			r = int(charAt(keys[idx[lt]], depth)) - v
+			if r > 0 {
+				break
+			} else if r == 0 {
+				idx[le], idx[lt] = idx[lt], idx[le]
+				le++
+			} else {
+				allzeros = false
+			}
+		}
+
+		// Move elements larger than pivot to the right.
+		for ; lt <= gt; gt-- {
+			r = int(charAt(keys[idx[gt]], depth)) - v
+			if r < 0 {
+				break
+			} else if r == 0 {
+				idx[gt], idx[ge] = idx[ge], idx[gt]
+				ge--
+			} else {
+				allzeros = false
+			}
+		}
+		if lt > gt {
+			break
+		}
+		idx[lt], idx[gt] = idx[gt], idx[lt]
+		lt++
+		gt--
+	}
+
+	pn = n
+	r = iMin(le, lt-le)
+	vecswapIndex(idx, 0, lt-r, r)
+	r = iMin(ge-gt, pn-ge-1)
+	vecswapIndex(idx, lt, pn-r, r)
+	r = lt - le
+	if r > 1 {
+		multikeyQuickSortIndex(keys, idx[:r], depth)
+	}
+	if !allzeros {
+		multikeyQuickSortIndex(keys, idx[r:r+le+n-ge-1], depth+1)
+	}
+	r = ge - gt
+	if r > 1 {
+		multikeyQuickSortIndex(keys, idx[n-r:], depth)
+	}
+}
+
+// vecswapIndex swaps the elements between two areas within a slice.
+func vecswapIndex(idx []int, src, dst, count int) {
+	for count > 0 {
+		idx[src], idx[dst] = idx[dst], idx[src]
+		src++
+		dst++
+		count--
+	}
+}
+
+// med3Index is identical to med3 except that the strings are found
+// indirectly through the indices in idx.
+func med3Index(keys []string, idx []int, low, med, high, depth int) int {
+	va := charAt(keys[idx[low]], depth)
+	vb := charAt(keys[idx[med]], depth)
+	if va == vb {
+		return low
+	}
+	vc := charAt(keys[idx[high]], depth)
+	if vc == va || vc == vb {
+		return high
+	}
+	if va < vb {
+		if vb < vc {
+			return med
+		} else if va < vc {
+			return high
+		}
+		return low
+	}
+	if vb > vc {
+		return med
+	} else if va < vc {
+		return low
+	}
+	return high
+}
+
+// insertionSortIndexDepth is identical to insertionSortDepth except
+// that it rearranges the indices in idx rather than the strings.
+func insertionSortIndexDepth(keys []string, idx []int, depth int) {
+	for i := 1; i < len(idx); i++ {
+		pivot := idx[i]
+		j := i
+		for j > 0 && compareTail(keys[pivot], keys[idx[j-1]], depth) < 0 {
+			idx[j] = idx[j-1]
+			j--
+		}
+		idx[j] = pivot
+	}
+}
+
+// IntroSortIndex returns the permutation of indices that sorts the
+// given strings, as determined by the introsort algorithm. The input
+// slice is not modified. The relative order of the indices of equal
+// strings is unspecified.
+func IntroSortIndex(a []string) []int {
+	size := len(a)
+	idx := identity(size)
+	if size < 2 {
+		return idx
+	}
+	floor := int(math.Floor(math.Log2(float64(size))))
+	introsortIndexLoop(0, size, 2*floor, a, idx)
+	insertionSortIndexDepth(a, idx, 0)
+	return idx
+}
+
+// introsortIndexLoop is identical to introsortLoop except that it
+// rearranges the indices in idx rather than the strings.
+func introsortIndexLoop(low, high, depth_limit int, a []string, idx []int) {
+	for high-low > 16 {
+		if depth_limit == 0 {
+			heapSortIndex(a, idx[low:high])
+			return
+		}
+		depth_limit--
+		x := a[idx[introsortIndexMedian(low, low+((high-low)/2)+1, high-1, a, idx)]]
+		p := introsortIndexPartition(low, high, x, a, idx)
+		introsortIndexLoop(p, high, depth_limit, a, idx)
+		high = p
+	}
+}
+
+// introsortIndexPartition partitions the indices in the given range
+// such that those of strings less than the pivot appear before those
+// of strings greater than the pivot.
+func introsortIndexPartition(low, high int, x string, a []string, idx []int) int {
+	i := low
+	j := high
+	for {
+		for a[idx[i]] < x {
+			i++
+		}
+		j--
+		for x < a[idx[j]] {
+			j--
+		}
+		if i >= j {
+			return i
+		}
+		idx[i], idx[j] = idx[j], idx[i]
+		i++
+	}
+}
+
+// introsortIndexMedian returns the position (low, mid, or high) of the
+// median of the three strings referenced by those positions.
+func introsortIndexMedian(low, mid, high int, a []string, idx []int) int {
+	l, m, h := a[idx[low]], a[idx[mid]], a[idx[high]]
+	if m < l {
+		if h < m {
+			return mid
+		} else if h < l {
+			return high
+		}
+		return low
+	}
+	if h < m {
+		if h < l {
+			return low
+		}
+		return high
+	}
+	return mid
+}
+
+// heapSortIndex sorts the indices in idx according to the strings to
+// which they refer, using the binary heap sort algorithm.
+func heapSortIndex(a []string, idx []int) {
+	size := len(idx)
+	siftDown := func(root, end int) {
+		for root*2+1 < end {
+			child := root*2 + 1
+			if child+1 < end && a[idx[child]] < a[idx[child+1]] {
+				child++
+			}
+			if a[idx[root]] < a[idx[child]] {
+				idx[root], idx[child] = idx[child], idx[root]
+				root = child
+			} else {
+				return
+			}
+		}
+	}
+	for start := (size - 2) / 2; start >= 0; start-- {
+		siftDown(start, size)
+	}
+	for end := size - 1; end > 0; end-- {
+		idx[0], idx[end] = idx[end], idx[0]
+		siftDown(0, end)
+	}
+}
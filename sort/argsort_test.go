@@ -0,0 +1,133 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"testing"
+)
+
+// indexSorter adapts an index returning sort function to the in-place
+// form expected by the test utilities, verifying along the way that
+// the result is a valid permutation of the input.
+func indexSorter(t *testing.T, f func([]string) []int) func([]string) {
+	return func(a []string) {
+		perm := f(a)
+		if len(perm) != len(a) {
+			t.Fatalf("permutation length %d != input length %d", len(perm), len(a))
+		}
+		seen := make([]bool, len(perm))
+		for _, p := range perm {
+			if p < 0 || p >= len(perm) || seen[p] {
+				t.Fatalf("invalid permutation entry %d", p)
+			}
+			seen[p] = true
+		}
+		ApplyPermutation(perm, a)
+	}
+}
+
+func TestBurstSortIndex(t *testing.T) {
+	f := indexSorter(t, BurstSortIndex)
+	testSortArguments(t, f)
+	testSortRepeated(t, f, smallDataSize)
+	testSortRepeatedCycle(t, f, smallDataSize)
+	testSortRandom(t, f, mediumDataSize)
+	testSortDictWords(t, f, mediumDataSize)
+	testSortReversed(t, f, mediumDataSize)
+	testSortNonUnique(t, f, mediumDataSize)
+}
+
+func TestMultikeyQuickSortIndex(t *testing.T) {
+	f := indexSorter(t, MultikeyQuickSortIndex)
+	testSortArguments(t, f)
+	testSortRepeated(t, f, mediumDataSize)
+	testSortRepeatedCycle(t, f, mediumDataSize)
+	testSortRandom(t, f, mediumDataSize)
+	testSortDictWords(t, f, mediumDataSize)
+	testSortReversed(t, f, mediumDataSize)
+	testSortNonUnique(t, f, mediumDataSize)
+}
+
+func TestIntroSortIndex(t *testing.T) {
+	f := indexSorter(t, IntroSortIndex)
+	testSortArguments(t, f)
+	testSortRepeated(t, f, mediumDataSize)
+	testSortRepeatedCycle(t, f, mediumDataSize)
+	testSortRandom(t, f, mediumDataSize)
+	testSortDictWords(t, f, mediumDataSize)
+	testSortReversed(t, f, mediumDataSize)
+	testSortNonUnique(t, f, mediumDataSize)
+}
+
+func TestIndexSortLeavesInput(t *testing.T) {
+	input := []string{"c", "a", "b"}
+	BurstSortIndex(input)
+	MultikeyQuickSortIndex(input)
+	IntroSortIndex(input)
+	if input[0] != "c" || input[1] != "a" || input[2] != "b" {
+		t.Error("index sort modified the input")
+	}
+}
+
+func TestApplyPermutation(t *testing.T) {
+	names := []string{"delta", "alpha", "charlie", "bravo", "echo"}
+	sizes := []int{4, 1, 3, 2, 5}
+	flags := []bool{false, true, false, true, false}
+	perm := IntroSortIndex(names)
+	ApplyPermutation(perm, names, sizes, flags)
+	expected := []string{"alpha", "bravo", "charlie", "delta", "echo"}
+	for i, name := range expected {
+		if names[i] != name {
+			t.Errorf("expected %s at %d, got %s", name, i, names[i])
+		}
+		if sizes[i] != i+1 {
+			t.Errorf("expected size %d at %d, got %d", i+1, i, sizes[i])
+		}
+	}
+	if flags[0] != true || flags[1] != true || flags[2] != false {
+		t.Error("parallel bool slice not permuted")
+	}
+	// no slices at all is permissible
+	ApplyPermutation(perm)
+}
+
+func TestApplyPermutationInvalid(t *testing.T) {
+	expectPanic := func(name string, f func()) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		f()
+	}
+	expectPanic("length", func() {
+		ApplyPermutation([]int{0, 1}, []string{"a"})
+	})
+	expectPanic("duplicate", func() {
+		ApplyPermutation([]int{1, 1, 0}, []string{"a", "b", "c"})
+	})
+	expectPanic("range", func() {
+		ApplyPermutation([]int{0, 3, 1}, []string{"a", "b", "c"})
+	})
+}
+
+// TestBurstSortIndexNullChain sorts the indices of strings that fill
+// the chained null buckets exactly, and then one more than that.
+func TestBurstSortIndexNullChain(t *testing.T) {
+	for _, n := range []int{thresholdMinusOne, 2 * thresholdMinusOne, 2*thresholdMinusOne + 1} {
+		input := make([]string, n+2)
+		for i := range input {
+			input[i] = "A"
+		}
+		input[0] = "B"
+		input[n+1] = ""
+		indexSorter(t, BurstSortIndex)(input)
+		if input[0] != "" || input[n+1] != "B" {
+			t.Errorf("expected empty string first and B last for %d strings", n)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// A thread-safe variant of the ring buffer, for passing values between
+// goroutines. Producers block in Put while the buffer is full, and
+// consumers block in Take while it is empty. Waiting may be cut short
+// by a context or a timeout, and by closing the buffer. Waiters are
+// woken by closing a signal channel, which is then replaced, so that
+// waiting can be combined with a context in a select statement. The
+// waiters are counted, so that the channels are only replaced when
+// there is someone to wake.
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBufferClosed is returned when putting a value into a closed
+// buffer, or taking a value from one that is both closed and empty.
+var ErrBufferClosed = errors.New("sort: ring buffer is closed")
+
+// blockingBufferIDs provides each BlockingRingBuffer with a unique
+// identifier, used to order the locking of buffers in Move.
+var blockingBufferIDs atomic.Uint64
+
+// BlockingRingBuffer is a fixed-size ring buffer that is safe for use
+// by multiple goroutines. The zero value is not usable; construct one
+// with NewBlockingRingBuffer.
+type BlockingRingBuffer[T any] struct {
+	mu       sync.Mutex
+	rb       *RingBuffer[T]
+	id       uint64        // unique identifier for lock ordering
+	notEmpty chan struct{} // closed when values are added
+	notFull  chan struct{} // closed when values are removed
+	takers   int           // number of goroutines waiting on notEmpty
+	putters  int           // number of goroutines waiting on notFull
+	closed   bool          // true once Close has been called
+}
+
+// NewBlockingRingBuffer constructs a BlockingRingBuffer with the given
+// capacity, which must be greater than zero.
+func NewBlockingRingBuffer[T any](capacity int) *BlockingRingBuffer[T] {
+	if capacity < 1 {
+		panic("sort: blocking ring buffer capacity must be positive")
+	}
+	return &BlockingRingBuffer[T]{
+		rb:       NewRingBuffer[T](capacity),
+		id:       blockingBufferIDs.Add(1),
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// signalNotEmpty wakes any goroutines waiting for values. The lock
+// must be held by the caller.
+func (bb *BlockingRingBuffer[T]) signalNotEmpty() {
+	if bb.takers > 0 {
+		close(bb.notEmpty)
+		bb.notEmpty = make(chan struct{})
+	}
+}
+
+// signalNotFull wakes any goroutines waiting for space. The lock must
+// be held by the caller.
+func (bb *BlockingRingBuffer[T]) signalNotFull() {
+	if bb.putters > 0 {
+		close(bb.notFull)
+		bb.notFull = make(chan struct{})
+	}
+}
+
+// wait counts the calling goroutine among the waiters, and releases
+// the lock while waiting for the signal channel to be closed or the
+// context to be done. The lock must be held by the caller, and is held
+// again upon return.
+func (bb *BlockingRingBuffer[T]) wait(ctx context.Context, signal <-chan struct{}, waiters *int) error {
+	*waiters++
+	bb.mu.Unlock()
+	err := waitSignal(ctx, signal)
+	bb.mu.Lock()
+	*waiters--
+	return err
+}
+
+// waitSignal blocks until the signal channel is closed or the context
+// is done, returning the error from the context in the latter case.
+func waitSignal(ctx context.Context, signal <-chan struct{}) error {
+	select {
+	case <-signal:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Put adds the value to the buffer, waiting for space to become
+// available if the buffer is full. Returns ErrBufferClosed if the
+// buffer is closed, or the context error if the context is done
+// before the value could be added.
+func (bb *BlockingRingBuffer[T]) Put(ctx context.Context, e T) error {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+	for {
+		if bb.closed {
+			return ErrBufferClosed
+		}
+		if bb.rb.Add(e) {
+			bb.signalNotEmpty()
+			return nil
+		}
+		if err := bb.wait(ctx, bb.notFull, &bb.putters); err != nil {
+			return err
+		}
+	}
+}
+
+// Take removes the first value from the buffer, waiting for one to be
+// added if the buffer is empty. Values remaining in a closed buffer may
+// still be taken; once it is empty, ErrBufferClosed is returned. If the
+// context is done before a value is available, the context error is
+// returned.
+func (bb *BlockingRingBuffer[T]) Take(ctx context.Context) (T, error) {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+	for {
+		if !bb.rb.Empty() {
+			e := bb.rb.Remove()
+			bb.signalNotFull()
+			return e, nil
+		}
+		var zero T
+		if bb.closed {
+			return zero, ErrBufferClosed
+		}
+		if err := bb.wait(ctx, bb.notEmpty, &bb.takers); err != nil {
+			return zero, err
+		}
+	}
+}
+
+// PutTimeout is like Put, but waits no longer than the given duration,
+// returning context.DeadlineExceeded if the time expires.
+func (bb *BlockingRingBuffer[T]) PutTimeout(e T, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return bb.Put(ctx, e)
+}
+
+// TakeTimeout is like Take, but waits no longer than the given
+// duration, returning context.DeadlineExceeded if the time expires.
+func (bb *BlockingRingBuffer[T]) TakeTimeout(timeout time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return bb.Take(ctx)
+}
+
+// Move transfers up to count values from this buffer to the sink in a
+// single step, waiting until this buffer has at least one value and
+// the sink has room for at least one. Returns the number of values
+// moved, which may be less than requested. Returns ErrBufferClosed if
+// the sink is closed, or if this buffer is closed and empty, including
+// when either happens while waiting.
+func (bb *BlockingRingBuffer[T]) Move(ctx context.Context, sink *BlockingRingBuffer[T], count int) (int, error) {
+	if sink == bb {
+		panic("sort: cannot move ring buffer contents to itself")
+	}
+	// always lock the buffers in the same order to avoid deadlock
+	first, second := bb, sink
+	if sink.id < bb.id {
+		first, second = sink, bb
+	}
+	first.mu.Lock()
+	second.mu.Lock()
+	defer func() {
+		second.mu.Unlock()
+		first.mu.Unlock()
+	}()
+	for {
+		if sink.closed || (bb.closed && bb.rb.Empty()) {
+			return 0, ErrBufferClosed
+		}
+		if !bb.rb.Empty() && !sink.rb.Full() {
+			moved := bb.rb.Move(sink.rb, count)
+			if moved > 0 {
+				bb.signalNotFull()
+				sink.signalNotEmpty()
+			}
+			return moved, nil
+		}
+		// Wait on both buffers, since either may be closed while waiting
+		// for the other, and closing signals both channels of a buffer.
+		// Both locks are released while waiting, as in wait.
+		notEmpty, notFull := bb.notEmpty, sink.notFull
+		bb.takers++
+		sink.putters++
+		second.mu.Unlock()
+		first.mu.Unlock()
+		var err error
+		select {
+		case <-notEmpty:
+		case <-notFull:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		first.mu.Lock()
+		second.mu.Lock()
+		bb.takers--
+		sink.putters--
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Close marks the buffer as closed, waking any goroutines blocked in
+// Put, Take, or Move. Subsequent calls to Put will fail, while Take
+// continues to return the remaining values. Closing a buffer more
+// than once has no effect.
+func (bb *BlockingRingBuffer[T]) Close() {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+	if !bb.closed {
+		bb.closed = true
+		bb.signalNotEmpty()
+		bb.signalNotFull()
+	}
+}
+
+// Capacity returns the total number of elements this buffer can hold.
+func (bb *BlockingRingBuffer[T]) Capacity() int {
+	return bb.rb.Capacity()
+}
+
+// Size returns the number of elements currently in the buffer.
+func (bb *BlockingRingBuffer[T]) Size() int {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+	return bb.rb.Size()
+}
@@ -0,0 +1,245 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingRingBufferTimeout(t *testing.T) {
+	bb := NewBlockingRingBuffer[string](2)
+	if _, err := bb.TakeTimeout(10 * time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("TakeTimeout() on empty buffer should time out, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := bb.PutTimeout(numbers[i], time.Second); err != nil {
+			t.Fatalf("PutTimeout() failed: %v", err)
+		}
+	}
+	if err := bb.PutTimeout("three", 10*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PutTimeout() on full buffer should time out, got %v", err)
+	}
+	if bb.Size() != 2 || bb.Capacity() != 2 {
+		t.Error("buffer has unexpected size or capacity")
+	}
+	for i := 0; i < 2; i++ {
+		if e, err := bb.TakeTimeout(time.Second); err != nil || e != numbers[i] {
+			t.Errorf("expected %s from TakeTimeout(), got %s (%v)", numbers[i], e, err)
+		}
+	}
+}
+
+func TestBlockingRingBufferCancel(t *testing.T) {
+	bb := NewBlockingRingBuffer[int](1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := bb.Take(ctx)
+		done <- err
+	}()
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Take() should return context.Canceled, got %v", err)
+	}
+	// a cancelled context does not prevent a put that need not wait
+	if err := bb.Put(ctx, 1); err != nil {
+		t.Errorf("Put() into buffer with space failed: %v", err)
+	}
+	if err := bb.Put(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Put() into full buffer should return context.Canceled, got %v", err)
+	}
+}
+
+func TestBlockingRingBufferClose(t *testing.T) {
+	bb := NewBlockingRingBuffer[int](4)
+	ctx := context.Background()
+	bb.Put(ctx, 1)
+	bb.Put(ctx, 2)
+	bb.Close()
+	bb.Close()
+	if err := bb.Put(ctx, 3); err != ErrBufferClosed {
+		t.Errorf("Put() into closed buffer should fail, got %v", err)
+	}
+	// remaining values can still be taken
+	for i := 1; i <= 2; i++ {
+		if e, err := bb.Take(ctx); err != nil || e != i {
+			t.Errorf("expected %d from Take(), got %d (%v)", i, e, err)
+		}
+	}
+	if _, err := bb.Take(ctx); err != ErrBufferClosed {
+		t.Errorf("Take() from closed, empty buffer should fail, got %v", err)
+	}
+	// closing wakes blocked goroutines
+	bb = NewBlockingRingBuffer[int](1)
+	done := make(chan error)
+	go func() {
+		_, err := bb.Take(ctx)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	bb.Close()
+	if err := <-done; err != ErrBufferClosed {
+		t.Errorf("blocked Take() should fail on Close(), got %v", err)
+	}
+}
+
+func TestBlockingRingBufferMove(t *testing.T) {
+	ctx := context.Background()
+	source := NewBlockingRingBuffer[string](10)
+	sink := NewBlockingRingBuffer[string](4)
+	for i := 0; i < 10; i++ {
+		source.Put(ctx, numbers[i])
+	}
+	if n, err := source.Move(ctx, sink, 10); err != nil || n != 4 {
+		t.Errorf("Move() should fill the sink, moved %d (%v)", n, err)
+	}
+	// the sink is full, so the next move waits for space
+	go func() {
+		for i := 0; i < 10; i++ {
+			if e, err := sink.Take(ctx); err != nil || e != numbers[i] {
+				t.Errorf("expected %s from Take(), got %s (%v)", numbers[i], e, err)
+			}
+		}
+	}()
+	moved := 4
+	for moved < 10 {
+		n, err := source.Move(ctx, sink, 10)
+		if err != nil {
+			t.Fatalf("Move() failed: %v", err)
+		}
+		moved += n
+	}
+	source.Close()
+	if _, err := source.Move(ctx, sink, 10); err != ErrBufferClosed {
+		t.Errorf("Move() from closed, empty buffer should fail, got %v", err)
+	}
+}
+
+func TestBlockingRingBufferMoveClose(t *testing.T) {
+	ctx := context.Background()
+	// closing the sink releases a move waiting for values
+	source := NewBlockingRingBuffer[int](2)
+	sink := NewBlockingRingBuffer[int](2)
+	done := make(chan error)
+	go func() {
+		_, err := source.Move(ctx, sink, 1)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	sink.Close()
+	if err := <-done; err != ErrBufferClosed {
+		t.Errorf("Move() waiting on empty source should fail on sink Close(), got %v", err)
+	}
+	// emptying and closing the source releases a move waiting for room
+	source = NewBlockingRingBuffer[int](2)
+	sink = NewBlockingRingBuffer[int](1)
+	source.Put(ctx, 1)
+	sink.Put(ctx, 2)
+	go func() {
+		_, err := source.Move(ctx, sink, 1)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	source.Take(ctx)
+	source.Close()
+	if err := <-done; err != ErrBufferClosed {
+		t.Errorf("Move() waiting on full sink should fail on source Close(), got %v", err)
+	}
+}
+
+func TestBlockingRingBufferAllocs(t *testing.T) {
+	// without any goroutines waiting, there are no signals to replace
+	ctx := context.Background()
+	bb := NewBlockingRingBuffer[int](4)
+	sink := NewBlockingRingBuffer[int](4)
+	allocs := testing.AllocsPerRun(100, func() {
+		bb.Put(ctx, 1)
+		bb.Put(ctx, 2)
+		bb.Move(ctx, sink, 1)
+		bb.Take(ctx)
+		sink.Take(ctx)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations when uncontended, got %v", allocs)
+	}
+}
+
+func TestBlockingRingBufferPipeline(t *testing.T) {
+	// several producers feed a stage that moves batches onward to
+	// several consumers, each of which verifies the order of values
+	// from every producer
+	const producers = 4
+	const consumers = 3
+	const perProducer = 2000
+	ctx := context.Background()
+	input := NewBlockingRingBuffer[[2]int](16)
+	output := NewBlockingRingBuffer[[2]int](8)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := input.Put(ctx, [2]int{p, i}); err != nil {
+					t.Errorf("Put() failed: %v", err)
+					return
+				}
+			}
+		}(p)
+	}
+	go func() {
+		wg.Wait()
+		input.Close()
+	}()
+	go func() {
+		for {
+			if _, err := input.Move(ctx, output, 5); err != nil {
+				if err != ErrBufferClosed {
+					t.Errorf("Move() failed: %v", err)
+				}
+				break
+			}
+		}
+		output.Close()
+	}()
+	var mu sync.Mutex
+	counts := make([]int, producers)
+	var cg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cg.Add(1)
+		go func() {
+			defer cg.Done()
+			last := make([]int, producers)
+			for i := range last {
+				last[i] = -1
+			}
+			for {
+				v, err := output.Take(ctx)
+				if err == ErrBufferClosed {
+					break
+				}
+				if v[1] <= last[v[0]] {
+					t.Errorf("value %v out of order", v)
+				}
+				last[v[0]] = v[1]
+				mu.Lock()
+				counts[v[0]]++
+				mu.Unlock()
+			}
+		}()
+	}
+	cg.Wait()
+	for p, n := range counts {
+		if n != perProducer {
+			t.Errorf("expected %d values from producer %d, got %d", perProducer, p, n)
+		}
+	}
+}
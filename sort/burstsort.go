@@ -6,6 +6,10 @@
 
 package sort
 
+import (
+	"iter"
+)
+
 // alphabetSize is the number of characters supported for the trie used
 // in sorting (strings are treated as arrays of uint8 values).
 const alphabetSize = 256
@@ -81,8 +85,9 @@ type burstNode struct {
 // character index into the trie. Presumably the character is from the
 // string, but not necessarily so. The character may be the null
 // character, in which case the string is added to the null bucket.
-// Buckets are expanded as needed to accomodate the new string.
-func (n *burstNode) add(c uint8, s string) {
+// Buckets are expanded as needed to accomodate the new string. The
+// value may also be an index referring to a string held elsewhere.
+func (n *burstNode) add(c uint8, s interface{}) {
 	// are buckets already created?
 	if n.counts[c] < 1 {
 		// need to create bucket
@@ -120,18 +125,42 @@ func (n *burstNode) add(c uint8, s string) {
 		} else {
 			// insert string in bucket and increment the item counter
 			b := n.elements[c].(bucket)
-			b = append(b, s)
-			n.counts[c]++
 			// when bucket fills, increase its size up to the threshold
 			l := len(b)
-			if n.counts[c] == l && n.counts[c] < threshold {
+			if l == cap(b) && l < threshold {
 				b = b.realloc(l, l*bucketGrowthFactor)
 			}
+			b = append(b, s)
+			n.counts[c]++
 			n.elements[c] = b
 		}
 	}
 }
 
+// nullBuckets returns an iterator over the daisy-chained null buckets,
+// each cut to the strings it holds, and so without the reference to the
+// next bucket in the chain.
+func (n *burstNode) nullBuckets() iter.Seq[bucket] {
+	return func(yield func(bucket) bool) {
+		count := n.size(nullterm)
+		if count <= 0 {
+			return
+		}
+		b := n.get(nullterm).(bucket)
+		for {
+			k := iMin(count, thresholdMinusOne)
+			if !yield(b[:k]) {
+				return
+			}
+			count -= k
+			if count == 0 {
+				return
+			}
+			b = b[thresholdMinusOne].(bucket)
+		}
+	}
+}
+
 // get retrieves either a trie node or a bucket for the given character.
 func (n *burstNode) get(c uint8) interface{} {
 	return n.elements[c]
@@ -155,49 +184,62 @@ func (n *burstNode) size(c uint8) int {
 // preparation for in-order traversal (hence sorting).
 func burstInsert(root *burstNode, strings []string) {
 	for _, word := range strings {
-		// start at root each time
-		curr := root
-		// locate trie node in which to insert string
-		p := 0
-		c := charAt(word, p)
-		for curr.size(c) < 0 {
-			curr = curr.get(c).(*burstNode)
-			p++
-			c = charAt(word, p)
-		}
+		burstInsertWord(root, word)
+	}
+}
 
-		curr.add(c, word)
+// burstInsertWord adds a single string into the burst trie structure,
+// bursting the bucket into a new trie node if it becomes too large.
+func burstInsertWord(root *burstNode, word string) {
+	// locate trie node in which to insert string
+	curr, c, p := burstLocate(root, word)
 
-		// This section is incredibly slow, and this is made
-		// worse when the input consists of long, repeated
-		// strings.
+	curr.add(c, word)
 
-		// is bucket size above the threshold?
-		for curr.size(c) >= threshold && c != nullterm {
-			// advance depth of character
-			p++
-			// allocate memory for new trie node
-			newt := new(burstNode)
-			// burst...
-			var cc uint8 = nullterm
-			ptrs := curr.get(c).(bucket)
-			size := curr.size(c)
-			for j := 0; j < size; j++ {
-				// access the next depth character
-				str := ptrs[j].(string)
-				cc = charAt(str, p)
-				newt.add(cc, str)
-			}
-			// old pointer points to the new trie node
-			curr.set(c, newt)
-			// used to burst recursive, so point curr to new
-			curr = newt
-			// point to character used in previous string
-			c = cc
+	// This section is incredibly slow, and this is made
+	// worse when the input consists of long, repeated
+	// strings.
+
+	// is bucket size above the threshold?
+	for curr.size(c) >= threshold && c != nullterm {
+		// advance depth of character
+		p++
+		// allocate memory for new trie node
+		newt := new(burstNode)
+		// burst...
+		var cc uint8 = nullterm
+		ptrs := curr.get(c).(bucket)
+		size := curr.size(c)
+		for j := 0; j < size; j++ {
+			// access the next depth character
+			str := ptrs[j].(string)
+			cc = charAt(str, p)
+			newt.add(cc, str)
 		}
+		// old pointer points to the new trie node
+		curr.set(c, newt)
+		// used to burst recursive, so point curr to new
+		curr = newt
+		// point to character used in previous string
+		c = cc
 	}
 }
 
+// burstLocate finds the trie node whose bucket would hold the given
+// string, returning the node, the character that selects the bucket,
+// and the depth of that character within the string.
+func burstLocate(root *burstNode, word string) (*burstNode, uint8, int) {
+	curr := root
+	p := 0
+	c := charAt(word, p)
+	for curr.size(c) < 0 {
+		curr = curr.get(c).(*burstNode)
+		p++
+		c = charAt(word, p)
+	}
+	return curr, c, p
+}
+
 // burstTraverse traverses the trie structure, ordering the strings in
 // the array to conform to their lexicographically sorted order as
 // determined by the trie structure.
@@ -213,24 +255,11 @@ func burstTraverse(node *burstNode, strings []string, pos, depth int) int {
 				// Visit all of the null buckets, which are daisy-chained
 				// together with the last reference in each bucket pointing
 				// to the next bucket in the chain.
-				num_buckets := (count / thresholdMinusOne) + 1
-				nullbucket := node.get(idx).(bucket)
-				for k := 1; k <= num_buckets; k++ {
-					var num_elements_in_bucket int
-					if k == num_buckets {
-						num_elements_in_bucket = count % thresholdMinusOne
-					} else {
-						num_elements_in_bucket = thresholdMinusOne
-					}
+				for b := range node.nullBuckets() {
 					// copy the string tails to the sorted array
-					j := 0
-					for j < num_elements_in_bucket {
-						strings[off] = nullbucket[j].(string)
+					for _, v := range b {
+						strings[off] = v.(string)
 						off++
-						j++
-					}
-					if nullbucket[j] != nil {
-						nullbucket = nullbucket[j].(bucket)
 					}
 				}
 			} else {
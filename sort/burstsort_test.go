@@ -101,3 +101,69 @@ func TestBurstSort(t *testing.T) {
 	testSortReversed(t, BurstSort, mediumDataSize)
 	testSortNonUnique(t, BurstSort, mediumDataSize)
 }
+
+// TestBurstSortNullChain sorts strings that fill the chained null
+// buckets exactly, and then one more than that.
+func TestBurstSortNullChain(t *testing.T) {
+	for _, n := range []int{thresholdMinusOne, 2 * thresholdMinusOne, 2*thresholdMinusOne + 1} {
+		input := make([]string, n+2)
+		for i := range input {
+			input[i] = "A"
+		}
+		input[0] = "B"
+		input[n+1] = ""
+		BurstSort(input)
+		if input[0] != "" || input[n+1] != "B" {
+			t.Errorf("expected empty string first and B last for %d strings", n)
+		}
+		for i := 1; i <= n; i++ {
+			if input[i] != "A" {
+				t.Fatalf("expected A at %d of %d, got %q", i, n, input[i])
+			}
+		}
+	}
+}
+
+func BenchmarkBurstSort(b *testing.B) {
+	for _, bm := range []struct {
+		name string
+		data []string
+	}{
+		{"Repeated", repeatedStrings[:smallDataSize]},
+		{"Random", randomStrings},
+		{"DictWords", uniqueWords},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			input := make([]string, len(bm.data))
+			for i := 0; i < b.N; i++ {
+				copy(input, bm.data)
+				BurstSort(input)
+			}
+		})
+	}
+}
+
+// TestBurstNullBuckets fills the chained null buckets and verifies
+// the number of strings visited in each.
+func TestBurstNullBuckets(t *testing.T) {
+	for _, n := range []int{1, thresholdMinusOne, thresholdMinusOne + 1, 2 * thresholdMinusOne} {
+		var node burstNode
+		for i := 0; i < n; i++ {
+			node.add(nullterm, i)
+		}
+		var sizes []int
+		next := 0
+		for b := range node.nullBuckets() {
+			sizes = append(sizes, len(b))
+			for _, v := range b {
+				if v.(int) != next {
+					t.Fatalf("expected %d in null bucket, got %v", next, v)
+				}
+				next++
+			}
+		}
+		if next != n || len(sizes) != (n-1)/thresholdMinusOne+1 {
+			t.Errorf("visited %d of %d values in buckets of sizes %v", next, n, sizes)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"iter"
+	"strings"
+)
+
+// BurstTrie is an ordered set of strings, built on the same burst trie
+// structure that is used by BurstSort. Strings are held in unsorted
+// buckets until a bucket grows too large, at which point it is burst
+// into a new trie node. Buckets are sorted as they are visited during
+// iteration. The zero value is an empty set ready to use.
+type BurstTrie struct {
+	root  *burstNode // root of the trie, allocated on first insert
+	count int        // number of strings in the set
+}
+
+// NewBurstTrie constructs an empty BurstTrie.
+func NewBurstTrie() *BurstTrie {
+	return new(BurstTrie)
+}
+
+// Insert adds the string to the set, returning true if it was added,
+// or false if the set already contained the string.
+func (t *BurstTrie) Insert(s string) bool {
+	if t.root == nil {
+		t.root = new(burstNode)
+	} else if t.Contains(s) {
+		return false
+	}
+	burstInsertWord(t.root, s)
+	t.count++
+	return true
+}
+
+// Contains returns true if the set contains the string.
+func (t *BurstTrie) Contains(s string) bool {
+	if t.root == nil {
+		return false
+	}
+	node, c, _ := burstLocate(t.root, s)
+	if node.size(c) == 0 {
+		return false
+	}
+	if c == nullterm {
+		b, _ := node.nullFind(s)
+		return b != nil
+	}
+	for _, v := range node.get(c).(bucket) {
+		if v.(string) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete removes the string from the set, returning true if it was
+// removed, or false if the set did not contain the string.
+func (t *BurstTrie) Delete(s string) bool {
+	if t.root == nil {
+		return false
+	}
+	node, c, _ := burstLocate(t.root, s)
+	if node.size(c) == 0 {
+		return false
+	}
+	if c == nullterm {
+		if !node.nullRemove(s) {
+			return false
+		}
+	} else if !node.remove(c, s) {
+		return false
+	}
+	t.count--
+	return true
+}
+
+// Len returns the number of strings in the set.
+func (t *BurstTrie) Len() int {
+	return t.count
+}
+
+// All returns an iterator over the strings in the set, in sorted order.
+// The set must not be modified during iteration.
+func (t *BurstTrie) All() iter.Seq[string] {
+	return t.Prefix("")
+}
+
+// Prefix returns an iterator over the strings in the set that begin
+// with the given prefix, in sorted order. The set must not be modified
+// during iteration.
+func (t *BurstTrie) Prefix(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if t.root == nil {
+			return
+		}
+		// Descend the trie nodes along the prefix for as far as they go.
+		node := t.root
+		depth := 0
+		for depth < len(prefix) {
+			c := prefix[depth]
+			if node.size(c) >= 0 {
+				// The prefix leads into a bucket, whose strings share
+				// the prefix up to this depth and possibly beyond.
+				matches := make([]string, 0, node.size(c))
+				node.each(c, func(s string) {
+					if strings.HasPrefix(s, prefix) {
+						matches = append(matches, s)
+					}
+				})
+				burstYield(matches, c, depth, yield)
+				return
+			}
+			node = node.get(c).(*burstNode)
+			depth++
+		}
+		burstWalk(node, depth, yield)
+	}
+}
+
+// burstWalk visits the strings in the trie rooted at node in sorted
+// order, passing each to yield. Returns false if yield returned false.
+func burstWalk(node *burstNode, depth int, yield func(string) bool) bool {
+	for c := 0; c < alphabetSize; c++ {
+		idx := uint8(c)
+		count := node.size(idx)
+		if count < 0 {
+			if !burstWalk(node.get(idx).(*burstNode), depth+1, yield) {
+				return false
+			}
+		} else if count > 0 {
+			strs := make([]string, 0, count)
+			node.each(idx, func(s string) {
+				strs = append(strs, s)
+			})
+			if !burstYield(strs, idx, depth, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// burstYield sorts the strings from the bucket selected by character c
+// at the given depth, then passes each to yield. Returns false if
+// yield returned false.
+func burstYield(strs []string, c uint8, depth int, yield func(string) bool) bool {
+	if len(strs) > 1 {
+		if c == nullterm || hasNull(strs) {
+			// Multikey quicksort takes a null character as the end of
+			// the string, so compare such strings in full. Those in the
+			// null bucket differ only if they contain null characters.
+			IntroSort(strs)
+		} else {
+			MultikeyQuickSortDepth(strs, depth+1)
+		}
+	}
+	for _, s := range strs {
+		if !yield(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// each invokes f for every string in the bucket for character c,
+// including the chained null buckets.
+func (n *burstNode) each(c uint8, f func(string)) {
+	count := n.size(c)
+	if count <= 0 {
+		return
+	}
+	if c != nullterm {
+		for _, v := range n.get(c).(bucket) {
+			f(v.(string))
+		}
+		return
+	}
+	for b := range n.nullBuckets() {
+		for _, v := range b {
+			f(v.(string))
+		}
+	}
+}
+
+// remove deletes the string from the (non-null) bucket for character c,
+// returning true if the string was found. The order of the strings in
+// the bucket is not preserved.
+func (n *burstNode) remove(c uint8, s string) bool {
+	b := n.get(c).(bucket)
+	for i, v := range b {
+		if v.(string) == s {
+			last := len(b) - 1
+			b[i] = b[last]
+			b[last] = nil
+			n.counts[c]--
+			if n.counts[c] == 0 {
+				n.elements[c] = nil
+			} else {
+				n.elements[c] = b[:last]
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// nullFind locates the string within the chained null buckets,
+// returning the bucket and the offset of the string within it, or a nil
+// bucket if the string was not found.
+func (n *burstNode) nullFind(s string) (bucket, int) {
+	for b := range n.nullBuckets() {
+		for i, v := range b {
+			if v.(string) == s {
+				return b, i
+			}
+		}
+	}
+	return nil, 0
+}
+
+// nullRemove deletes the string from the chained null buckets,
+// returning true if the string was found. The last string in the chain
+// takes the place of the removed string, and if that leaves the last
+// bucket empty, the bucket is unlinked from the chain.
+func (n *burstNode) nullRemove(s string) bool {
+	b, i := n.nullFind(s)
+	if b == nil {
+		return false
+	}
+	n.nulltailidx--
+	b[i] = n.nulltail[n.nulltailidx]
+	n.nulltail[n.nulltailidx] = nil
+	n.counts[nullterm]--
+	if n.counts[nullterm] == 0 {
+		n.elements[nullterm] = nil
+		n.nulltail = nil
+		n.nulltailidx = 0
+	} else if n.nulltailidx == 0 {
+		// find the bucket that links to the now empty tail bucket
+		prev := n.get(nullterm).(bucket)
+		for {
+			next := prev[thresholdMinusOne].(bucket)
+			if &next[0] == &n.nulltail[0] {
+				break
+			}
+			prev = next
+		}
+		prev[thresholdMinusOne] = nil
+		n.nulltail = prev
+		n.nulltailidx = thresholdMinusOne
+	}
+	return true
+}
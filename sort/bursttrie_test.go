@@ -0,0 +1,178 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// checkTrieContents compares the ordered contents of the trie against
+// the expected (sorted) strings.
+func checkTrieContents(t *testing.T, trie *BurstTrie, expected []string) {
+	if trie.Len() != len(expected) {
+		t.Fatalf("expected Len() %d, got %d", len(expected), trie.Len())
+	}
+	actual := slices.Collect(trie.All())
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d strings from All(), got %d", len(expected), len(actual))
+	}
+	for i, s := range expected {
+		if actual[i] != s {
+			t.Fatalf("expected %q at %d, got %q", s, i, actual[i])
+		}
+	}
+}
+
+func TestBurstTrieEmpty(t *testing.T) {
+	var trie BurstTrie
+	if trie.Len() != 0 {
+		t.Error("zero value should be empty")
+	}
+	if trie.Contains("") || trie.Contains("a") {
+		t.Error("empty trie should contain nothing")
+	}
+	if trie.Delete("a") {
+		t.Error("Delete() on empty trie should return false")
+	}
+	for range trie.All() {
+		t.Error("empty trie should yield nothing")
+	}
+	if !trie.Insert("") || !trie.Contains("") || trie.Insert("") {
+		t.Error("empty string not handled as a member")
+	}
+	checkTrieContents(t, &trie, []string{""})
+}
+
+func TestBurstTrieInsertDelete(t *testing.T) {
+	trie := NewBurstTrie()
+	// the non-unique words are enough to cause bursting, and many of
+	// them are duplicates that must be rejected
+	expected := make(map[string]bool)
+	for _, w := range nonUniqueWords {
+		if trie.Insert(w) == expected[w] {
+			t.Fatalf("Insert(%q) disagrees with prior membership", w)
+		}
+		expected[w] = true
+	}
+	sorted := make([]string, 0, len(expected))
+	for w := range expected {
+		sorted = append(sorted, w)
+	}
+	sort.Strings(sorted)
+	checkTrieContents(t, trie, sorted)
+
+	// delete every other word and verify the remainder
+	remaining := make([]string, 0, len(sorted)/2)
+	for i, w := range sorted {
+		if i%2 == 0 {
+			if !trie.Delete(w) {
+				t.Fatalf("Delete(%q) failed", w)
+			}
+			if trie.Delete(w) {
+				t.Fatalf("Delete(%q) succeeded twice", w)
+			}
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	for i, w := range sorted {
+		if trie.Contains(w) != (i%2 == 1) {
+			t.Fatalf("Contains(%q) incorrect after Delete()", w)
+		}
+	}
+	checkTrieContents(t, trie, remaining)
+}
+
+func TestBurstTriePrefix(t *testing.T) {
+	trie := NewBurstTrie()
+	words := uniqueWords[:mediumDataSize]
+	for _, w := range words {
+		trie.Insert(w)
+	}
+	sorted := make([]string, len(words))
+	copy(sorted, words)
+	sort.Strings(sorted)
+	for _, prefix := range []string{"", "a", "q", "zz", "abc", "mnopq", "nosuchprefixatall"} {
+		var expected []string
+		for _, w := range sorted {
+			if strings.HasPrefix(w, prefix) {
+				expected = append(expected, w)
+			}
+		}
+		actual := slices.Collect(trie.Prefix(prefix))
+		if !slices.Equal(actual, expected) {
+			t.Errorf("Prefix(%q) yielded %d strings, expected %d", prefix, len(actual), len(expected))
+		}
+	}
+	// stopping early must be honored
+	n := 0
+	for range trie.Prefix("a") {
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Error("iteration did not stop when requested")
+	}
+}
+
+func TestBurstTrieNullChain(t *testing.T) {
+	// Strings that differ only after a null character all land in the
+	// same null bucket, enough of them to require a chain of buckets.
+	trie := NewBurstTrie()
+	var expected []string
+	for i := 0; i < thresholdMinusOne+10; i++ {
+		s := fmt.Sprintf("x\000%05d", i)
+		trie.Insert(s)
+		expected = append(expected, s)
+	}
+	checkTrieContents(t, trie, expected)
+	// remove enough to unlink the tail buckets
+	for len(expected) > thresholdMinusOne-5 {
+		s := expected[len(expected)/2]
+		if !trie.Delete(s) {
+			t.Fatalf("Delete(%q) failed", s)
+		}
+		expected = slices.Delete(expected, len(expected)/2, len(expected)/2+1)
+	}
+	checkTrieContents(t, trie, expected)
+	// and add some back again to extend the chain once more
+	for i := 0; i < 20; i++ {
+		s := fmt.Sprintf("x\000y%d", i)
+		trie.Insert(s)
+		expected = append(expected, s)
+	}
+	sort.Strings(expected)
+	checkTrieContents(t, trie, expected)
+}
+
+func TestBurstTrieNulls(t *testing.T) {
+	// Strings with embedded null characters in a bucket that has not
+	// been burst, ordered byte-wise rather than ending at the null.
+	trie := NewBurstTrie()
+	words := []string{"a\x00c", "a", "a\x00b", "a\x00", "b\x00", "b"}
+	for _, w := range words {
+		trie.Insert(w)
+	}
+	expected := []string{"a", "a\x00", "a\x00b", "a\x00c", "b", "b\x00"}
+	checkTrieContents(t, trie, expected)
+	if actual := slices.Collect(trie.Prefix("a\x00")); !slices.Equal(actual, expected[1:4]) {
+		t.Errorf("Prefix(%q) yielded %q", "a\x00", actual)
+	}
+	// and in buckets below a trie node
+	for i := 0; i < threshold; i++ {
+		w := fmt.Sprintf("n%d\x00%d", i%10, i)
+		trie.Insert(w)
+		expected = append(expected, w)
+	}
+	sort.Strings(expected)
+	checkTrieContents(t, trie, expected)
+}
@@ -0,0 +1,138 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// The Burrows-Wheeler transform (BWT) and its inverse, along with the
+// move-to-front transform that typically follows it in block-sorting
+// compressors. The forward transform is computed from the suffix array
+// of the input, with an implicit end-of-text marker that sorts before
+// every byte value. The position of that marker within the transformed
+// output is returned as the primary index, and the marker itself is
+// omitted, so the output is the same length as the input.
+
+import (
+	"errors"
+)
+
+// ErrPrimaryIndex is returned by InverseBWT when the primary index is
+// out of range for the given data.
+var ErrPrimaryIndex = errors.New("sort: BWT primary index out of range")
+
+// BWT computes the Burrows-Wheeler transform of the data, returning the
+// transformed bytes and the primary index needed to invert it.
+func BWT(data []byte) ([]byte, int) {
+	n := len(data)
+	out := make([]byte, n)
+	if n == 0 {
+		return out, 0
+	}
+	sa := SuffixArray(data)
+	// The first rotation is the one that begins with the end marker,
+	// which is preceded by the last byte of the data.
+	out[0] = data[n-1]
+	primary := 0
+	k := 1
+	for i, p := range sa {
+		if p == 0 {
+			// this rotation is preceded by the end marker
+			primary = i + 1
+		} else {
+			out[k] = data[p-1]
+			k++
+		}
+	}
+	return out, primary
+}
+
+// InverseBWT reverses the Burrows-Wheeler transform, given the output
+// of BWT and its primary index.
+func InverseBWT(data []byte, primary int) ([]byte, error) {
+	n := len(data)
+	if primary < 0 || primary > n || (n > 0 && primary == 0) {
+		return nil, ErrPrimaryIndex
+	}
+	out := make([]byte, n)
+	if n == 0 {
+		return out, nil
+	}
+	// Count the bytes less than each byte value; the end marker is
+	// less than all of them and occupies the first row.
+	var base [alphabetSize]int
+	for _, c := range data {
+		base[c]++
+	}
+	sum := 1
+	for c := range base {
+		base[c], sum = sum, sum+base[c]
+	}
+	// Compute the last-to-first mapping for every row but the one
+	// ending with the end marker.
+	lf := make([]int, n+1)
+	var occ [alphabetSize]int
+	for r := 0; r <= n; r++ {
+		if r == primary {
+			continue
+		}
+		c := bwtRowByte(data, primary, r)
+		lf[r] = base[c] + occ[c]
+		occ[c]++
+	}
+	// Walk backward through the text, starting from the first row.
+	r := 0
+	for k := n - 1; k >= 0; k-- {
+		out[k] = bwtRowByte(data, primary, r)
+		r = lf[r]
+	}
+	return out, nil
+}
+
+// bwtRowByte returns the last byte of the given row of the transform,
+// accounting for the omitted end marker at the primary index.
+func bwtRowByte(data []byte, primary, r int) byte {
+	if r < primary {
+		return data[r]
+	}
+	return data[r-1]
+}
+
+// MoveToFrontEncode applies the move-to-front transform to the data,
+// replacing each byte with its position in a list of recently used
+// byte values, then moving that value to the front of the list. Runs
+// of identical bytes, as produced by BWT, become runs of zeros.
+func MoveToFrontEncode(data []byte) []byte {
+	var order [alphabetSize]byte
+	for i := range order {
+		order[i] = byte(i)
+	}
+	out := make([]byte, len(data))
+	for i, c := range data {
+		j := 0
+		for order[j] != c {
+			j++
+		}
+		out[i] = byte(j)
+		copy(order[1:j+1], order[:j])
+		order[0] = c
+	}
+	return out
+}
+
+// MoveToFrontDecode reverses the move-to-front transform.
+func MoveToFrontDecode(data []byte) []byte {
+	var order [alphabetSize]byte
+	for i := range order {
+		order[i] = byte(i)
+	}
+	out := make([]byte, len(data))
+	for i, j := range data {
+		c := order[j]
+		out[i] = c
+		copy(order[1:int(j)+1], order[:j])
+		order[0] = c
+	}
+	return out
+}
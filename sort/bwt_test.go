@@ -0,0 +1,95 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testBWTRoundTrip transforms the data with BWT and move-to-front, then
+// inverts both and compares the result with the original data.
+func testBWTRoundTrip(t *testing.T, name string, data []byte) {
+	transformed, primary := BWT(data)
+	if len(transformed) != len(data) {
+		t.Fatalf("%s: BWT changed length from %d to %d", name, len(data), len(transformed))
+	}
+	encoded := MoveToFrontEncode(transformed)
+	decoded := MoveToFrontDecode(encoded)
+	if !bytes.Equal(decoded, transformed) {
+		t.Fatalf("%s: move-to-front round trip failed", name)
+	}
+	original, err := InverseBWT(decoded, primary)
+	if err != nil {
+		t.Fatalf("%s: InverseBWT failed: %v", name, err)
+	}
+	if !bytes.Equal(original, data) {
+		t.Fatalf("%s: BWT round trip failed", name)
+	}
+}
+
+func TestBWTKnown(t *testing.T) {
+	out, primary := BWT([]byte("banana"))
+	if string(out) != "annbaa" || primary != 4 {
+		t.Errorf("expected annbaa/4, got %s/%d", out, primary)
+	}
+	in, err := InverseBWT(out, primary)
+	if err != nil || string(in) != "banana" {
+		t.Errorf("expected banana, got %s (%v)", in, err)
+	}
+	mtf := MoveToFrontEncode([]byte("aaabbbaaa"))
+	if !bytes.Equal(mtf, []byte{97, 0, 0, 98, 0, 0, 1, 0, 0}) {
+		t.Errorf("unexpected move-to-front output %v", mtf)
+	}
+}
+
+func TestBWTArguments(t *testing.T) {
+	testBWTRoundTrip(t, "empty", []byte{})
+	testBWTRoundTrip(t, "single", []byte{0})
+	testBWTRoundTrip(t, "nulls", make([]byte, 100))
+	testBWTRoundTrip(t, "repeated", []byte(strings.Repeat("A", 1000)))
+	if _, err := InverseBWT([]byte("abc"), 4); err != ErrPrimaryIndex {
+		t.Error("expected error for primary index past end")
+	}
+	if _, err := InverseBWT([]byte("abc"), 0); err != ErrPrimaryIndex {
+		t.Error("expected error for primary index of zero")
+	}
+}
+
+func TestBWTDataSets(t *testing.T) {
+	sets := map[string][]string{
+		"genome":         genomeStrings,
+		"small alphabet": smallAlphaStrings,
+		"random":         randomStrings,
+		"dictwords":      uniqueWords,
+	}
+	for name, data := range sets {
+		block := []byte(strings.Join(data[:mediumDataSize/16], "\n"))
+		testBWTRoundTrip(t, name, block)
+	}
+}
+
+func TestBWTCompressibility(t *testing.T) {
+	// The transform should help the compressibility of text with
+	// repeated substrings, as evidenced by more zeros after
+	// move-to-front. The generated data sets are random and have too
+	// little such structure to show it reliably, so use a fixed text.
+	var text strings.Builder
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(&text, "line %d: the quick brown fox jumps over the lazy dog\n", i)
+	}
+	block := []byte(text.String())
+	testBWTRoundTrip(t, "text", block)
+	transformed, _ := BWT(block)
+	before := bytes.Count(MoveToFrontEncode(block), []byte{0})
+	after := bytes.Count(MoveToFrontEncode(transformed), []byte{0})
+	if after <= 2*before {
+		t.Errorf("BWT did not increase runs (%d zeros before, %d after)", before, after)
+	}
+}
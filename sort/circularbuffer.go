@@ -5,35 +5,45 @@
 package sort
 
 // A simple circular buffer of fixed length which has an empty and full
-// state. When full, the buffer will not accept any new entries.
+// state. When full, the buffer will not accept any new entries, unless
+// it has been allowed to grow, in which case the capacity is doubled.
 
-// CircularBuffer is an array of elements that is fixed in size and thus
-// will stop receiving new elements once full. Removing elements makes
-// room for new ones. Buffers may share a single underlying slice if
-// given lower and upper bounds that do not overlap.
-type CircularBuffer struct {
-	buffer []interface{} // contains the buffer elements
-	start  int           // position of first element
-	end    int           // position of last element
-	count  int           // number of elements
+import (
+	"iter"
+)
+
+// RingBuffer is an array of elements of type T that is fixed in size
+// and thus will stop receiving new elements once full, unless growth
+// has been enabled with SetAutoGrow. Removing elements makes room for
+// new ones. Buffers may share a single underlying slice if given lower
+// and upper bounds that do not overlap.
+type RingBuffer[T any] struct {
+	buffer []T  // contains the buffer elements
+	start  int  // position of first element
+	end    int  // position after the last element
+	count  int  // number of elements
+	grow   bool // if true, grow the buffer when full
 }
 
-// NewCircularBuffer constructs a CircularBuffer with the given capacity.
-func NewCircularBuffer(capacity int) *CircularBuffer {
-	cb := new(CircularBuffer)
-	cb.buffer = make([]interface{}, capacity)
+// CircularBuffer is a RingBuffer that holds values of any type.
+type CircularBuffer = RingBuffer[interface{}]
+
+// NewRingBuffer constructs a RingBuffer with the given capacity.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	cb := new(RingBuffer[T])
+	cb.buffer = make([]T, capacity)
 	return cb
 }
 
-// NewCircularBufferFromSlice constructs a CircularBuffer from the given
-// slice. All entries in the slice are assumed to be valid data such
-// that the buffer count will be equal to the length of the given slice.
-// If dupe is true, will create a new slice and copy the contents of
-// initial to that slice.
-func NewCircularBufferFromSlice(initial []interface{}, dupe bool) *CircularBuffer {
-	cb := new(CircularBuffer)
+// NewRingBufferFromSlice constructs a RingBuffer from the given slice.
+// All entries in the slice are assumed to be valid data such that the
+// buffer count, and its capacity, will be equal to the length of the
+// given slice. If dupe is true, will create a new slice and copy the
+// contents of initial to that slice.
+func NewRingBufferFromSlice[T any](initial []T, dupe bool) *RingBuffer[T] {
+	cb := new(RingBuffer[T])
 	if dupe {
-		cb.buffer = make([]interface{}, 0, len(initial))
+		cb.buffer = make([]T, len(initial))
 		copy(cb.buffer, initial)
 	} else {
 		cb.buffer = initial
@@ -42,11 +52,62 @@ func NewCircularBufferFromSlice(initial []interface{}, dupe bool) *CircularBuffe
 	return cb
 }
 
+// NewCircularBuffer constructs a CircularBuffer with the given capacity.
+func NewCircularBuffer(capacity int) *CircularBuffer {
+	return NewRingBuffer[interface{}](capacity)
+}
+
+// NewCircularBufferFromSlice constructs a CircularBuffer from the given
+// slice, as with NewRingBufferFromSlice.
+func NewCircularBufferFromSlice(initial []interface{}, dupe bool) *CircularBuffer {
+	return NewRingBufferFromSlice(initial, dupe)
+}
+
+// SetAutoGrow controls whether the buffer grows to make room for new
+// elements when full, rather than rejecting them. Growing the buffer
+// allocates a new slice, which is no longer shared with other buffers.
+func (cb *RingBuffer[T]) SetAutoGrow(grow bool) {
+	cb.grow = grow
+}
+
+// Grow increases the capacity of the buffer, if necessary, to make room
+// for another n elements. The elements are moved to the start of a new
+// slice, preserving their order.
+func (cb *RingBuffer[T]) Grow(n int) {
+	needed := cb.count + n
+	if needed <= len(cb.buffer) {
+		return
+	}
+	capacity := iMax(needed, 2*len(cb.buffer))
+	buffer := make([]T, capacity)
+	head, tail := cb.segments()
+	copy(buffer[copy(buffer, head):], tail)
+	cb.buffer = buffer
+	cb.start = 0
+	cb.end = cb.count
+}
+
+// segments returns the contents of the buffer in order, as two slices
+// of the underlying buffer, the second of which is empty unless the
+// contents wrap around the end of the buffer.
+func (cb *RingBuffer[T]) segments() ([]T, []T) {
+	if cb.count == 0 {
+		return nil, nil
+	}
+	if cb.start+cb.count <= len(cb.buffer) {
+		return cb.buffer[cb.start : cb.start+cb.count], nil
+	}
+	return cb.buffer[cb.start:], cb.buffer[:cb.start+cb.count-len(cb.buffer)]
+}
+
 // Add adds the given value to the buffer, returning true if
-// successful, or false if the buffer is full.
-func (cb *CircularBuffer) Add(e interface{}) bool {
-	if cb.count == cap(cb.buffer) {
-		return false
+// successful, or false if the buffer is full and cannot grow.
+func (cb *RingBuffer[T]) Add(e T) bool {
+	if cb.count == len(cb.buffer) {
+		if !cb.grow {
+			return false
+		}
+		cb.Grow(1)
 	}
 	cb.count++
 	cb.buffer[cb.end] = e
@@ -57,44 +118,84 @@ func (cb *CircularBuffer) Add(e interface{}) bool {
 	return true
 }
 
-// Capacity returns the total number of elements this buffer can hold.
-func (cb *CircularBuffer) Capacity() int {
-	return cap(cb.buffer)
+// AddAll adds as many of the given values to the buffer as will fit,
+// in order, returning the number that were added. If the buffer is
+// allowed to grow, all of the values are added.
+func (cb *RingBuffer[T]) AddAll(values []T) int {
+	if cb.grow {
+		cb.Grow(len(values))
+	}
+	n := iMin(len(values), len(cb.buffer)-cb.count)
+	if n == 0 {
+		return 0
+	}
+	// copy up to the end of the buffer, then wrap around to the start
+	copied := copy(cb.buffer[cb.end:], values[:n])
+	copy(cb.buffer, values[copied:n])
+	cb.end = (cb.end + n) % len(cb.buffer)
+	cb.count += n
+	return n
 }
 
-// Drain moves the contents of the circular buffer into the given output
-// slice in an efficient manner, leaving this buffer empty. Returns the
-// number of elements copied to the slice.
-func (cb *CircularBuffer) Drain(sink []interface{}) int {
-	if cb.count == 0 {
-		return 0 // nothing to copy
+// At returns the element at the given offset from the start of the
+// buffer, without removing it. Panics if the offset is out of range.
+func (cb *RingBuffer[T]) At(i int) T {
+	if i < 0 || i >= cb.count {
+		panic("sort: ring buffer index out of range")
 	}
-	if cap(sink)-len(sink) < cb.count {
-		return 0 // insufficient space
+	i += cb.start
+	if i >= len(cb.buffer) {
+		i -= len(cb.buffer)
 	}
-	if cb.end <= cb.start {
-		// elements wrap around, must make two calls to copy()
-		copy(sink, cb.buffer[cb.start:])
-		copy(sink, cb.buffer[:cb.end+1])
-	} else {
-		// elements are in one contiguous region
-		copy(sink, cb.buffer[cb.start:cb.end+1])
+	return cb.buffer[i]
+}
+
+// All returns an iterator over the elements of the buffer, from first
+// to last, without removing them. The buffer must not be modified
+// during the iteration.
+func (cb *RingBuffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		head, tail := cb.segments()
+		for _, e := range head {
+			if !yield(e) {
+				return
+			}
+		}
+		for _, e := range tail {
+			if !yield(e) {
+				return
+			}
+		}
 	}
+}
+
+// Capacity returns the total number of elements this buffer can hold.
+func (cb *RingBuffer[T]) Capacity() int {
+	return len(cb.buffer)
+}
+
+// Drain appends the contents of the circular buffer to the given
+// slice, in order, leaving this buffer empty. As with the built-in
+// append, the sink is grown if it lacks the capacity, and the updated
+// slice is returned.
+func (cb *RingBuffer[T]) Drain(sink []T) []T {
+	head, tail := cb.segments()
+	sink = append(sink, head...)
+	sink = append(sink, tail...)
 	cb.start = 0
 	cb.end = 0
-	count := cb.count
 	cb.count = 0
-	return count
+	return sink
 }
 
 // Empty returns true if the buffer is empty, false otherwise.
-func (cb *CircularBuffer) Empty() bool {
+func (cb *RingBuffer[T]) Empty() bool {
 	return cb.count == 0
 }
 
 // Full returns true if the buffer is full, false otherwise.
-func (cb *CircularBuffer) Full() bool {
-	return cb.count == cap(cb.buffer)
+func (cb *RingBuffer[T]) Full() bool {
+	return cb.count == len(cb.buffer)
 }
 
 // Move removes the given number of elements from the circular buffer
@@ -103,7 +204,7 @@ func (cb *CircularBuffer) Full() bool {
 // adding them to the sink. Returns the number of elements moved, which
 // may be less than requested if the origin has fewer items, or if the
 // sink has insufficient space.
-func (cb *CircularBuffer) Move(sink *CircularBuffer, count int) int {
+func (cb *RingBuffer[T]) Move(sink *RingBuffer[T], count int) int {
 	if cb.count < count {
 		count = cb.count
 	}
@@ -111,8 +212,8 @@ func (cb *CircularBuffer) Move(sink *CircularBuffer, count int) int {
 		count = sink.Remaining()
 	}
 	tocopy := count
-	capacity := cap(cb.buffer)
-	sapacity := cap(sink.buffer)
+	capacity := len(cb.buffer)
+	sapacity := len(sink.buffer)
 	for tocopy > 0 {
 		// compute how much can be copied from source
 		var available int
@@ -130,7 +231,7 @@ func (cb *CircularBuffer) Move(sink *CircularBuffer, count int) int {
 			// wraps around
 			willfit = sink.start - sink.end
 		}
-		willcopy := iMin(available, willfit)
+		willcopy := iMin(tocopy, iMin(available, willfit))
 		if willcopy <= 0 {
 			break
 		}
@@ -154,36 +255,52 @@ func (cb *CircularBuffer) Move(sink *CircularBuffer, count int) int {
 }
 
 // Peek returns the first element in the buffer, without removing it.
-// If the buffer is empty, nil is returned.
-func (cb *CircularBuffer) Peek() interface{} {
+// If the buffer is empty, the zero value (nil for a CircularBuffer) is
+// returned.
+func (cb *RingBuffer[T]) Peek() T {
 	if cb.count == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	return cb.buffer[cb.start]
 }
 
 // Remove removes the first element in the buffer, reducing the
 // number of elements in the buffer by one. If the buffer is
-// empty, nil is returned.
-func (cb *CircularBuffer) Remove() interface{} {
+// empty, the zero value (nil for a CircularBuffer) is returned.
+func (cb *RingBuffer[T]) Remove() T {
 	if cb.count == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	cb.count--
 	e := cb.buffer[cb.start]
 	cb.start++
-	if cb.start == cap(cb.buffer) {
+	if cb.start == len(cb.buffer) {
 		cb.start = 0
 	}
 	return e
 }
 
+// RemoveN removes up to n elements from the start of the buffer,
+// returning them in a new slice, in order.
+func (cb *RingBuffer[T]) RemoveN(n int) []T {
+	n = iMax(iMin(n, cb.count), 0)
+	result := make([]T, n)
+	head, tail := cb.segments()
+	copied := copy(result, head)
+	copy(result[copied:], tail)
+	cb.start = (cb.start + n) % iMax(len(cb.buffer), 1)
+	cb.count -= n
+	return result
+}
+
 // Remaining returns the number of empty spaces within this buffer.
-func (cb *CircularBuffer) Remaining() int {
-	return cap(cb.buffer) - cb.count
+func (cb *RingBuffer[T]) Remaining() int {
+	return len(cb.buffer) - cb.count
 }
 
 // Size returns the number of elements in the circular buffer.
-func (cb *CircularBuffer) Size() int {
+func (cb *RingBuffer[T]) Size() int {
 	return cb.count
 }
@@ -137,23 +137,20 @@ func TestCircularBufferAdd(t *testing.T) {
 func TestCircularBufferDrain(t *testing.T) {
 	cb := NewCircularBuffer(10)
 	sink := make([]interface{}, 0, 10)
-	count := cb.Drain(sink)
-	if count != 0 {
-		t.Error("Drain() with empty buffer should return zero")
+	sink = cb.Drain(sink)
+	if len(sink) != 0 {
+		t.Error("Drain() with empty buffer should append nothing")
 	}
 	// fill the buffer with unique values
 	for i := 0; cb.Remaining() > 0; i++ {
 		cb.Add(numbers[i])
 	}
+	// the sink is grown as needed, keeping its existing contents
 	sink = make([]interface{}, 0, 5)
-	count = cb.Drain(sink)
-	if count != 0 {
-		t.Error("Drain() with small sink should return zero")
-	}
-	sink = make([]interface{}, 0, 10)
-	count = cb.Drain(sink)
-	if count != 10 {
-		t.Error("Drain() should return full contents")
+	sink = append(sink, "zero")
+	sink = cb.Drain(sink)
+	if len(sink) != 11 {
+		t.Errorf("Drain() should append full contents, got %d", len(sink))
 	}
 	if cb.Remaining() != 10 {
 		t.Error("Remaining() does not match available space")
@@ -171,51 +168,48 @@ func TestCircularBufferDrain(t *testing.T) {
 		t.Error("Peek() should return nil for empty buffer")
 	}
 	// examine the results to ensure they are correct
-	for i, v := range sink {
+	if sink[0].(string) != "zero" {
+		t.Error("Drain() overwrote existing contents of sink")
+	}
+	for i, v := range sink[1:] {
 		if v.(string) != numbers[i] {
-			t.Error("results of Drain() do not match input")
+			t.Errorf("expected %s from Drain() at %d, got %s", numbers[i], i, v)
 		}
 	}
 }
 
 func TestCircularBufferDrainWrap(t *testing.T) {
-	cb := NewCircularBuffer(10)
-	// add some elements and then remove them so that the next batch
-	// we add will end up wrapping around within the buffer
-	for i := 0; i < 5; i++ {
-		cb.Add(numbers[i])
-	}
-	for cb.Size() > 0 {
-		cb.Remove()
-	}
-	// now fill the buffer with unique values
-	for i := 0; cb.Remaining() > 0; i++ {
-		cb.Add(numbers[i])
-	}
-	sink := make([]interface{}, 0, 10)
-	count := cb.Drain(sink)
-	if count != 10 {
-		t.Error("Drain() should return full contents")
-	}
-	if cb.Remaining() != 10 {
-		t.Error("Remaining() does not match available space")
-	}
-	if cb.Size() != 0 {
-		t.Error("Size() should be zero after Drain()")
-	}
-	if !cb.Empty() {
-		t.Error("Empty() should return true after Drain()")
-	}
-	if cb.Full() {
-		t.Error("Full() should return false when Remaining() > 0")
-	}
-	if cb.Peek() != nil {
-		t.Error("Peek() should return nil for empty buffer")
-	}
-	// examine the results to ensure they are correct
-	for i, v := range sink {
-		if v.(string) != numbers[i] {
-			t.Error("results of Drain() do not match input")
+	// vary the point at which the contents wrap around, as well as the
+	// number of elements in the buffer
+	for offset := 0; offset < 10; offset++ {
+		for size := 0; size <= 10; size++ {
+			cb := NewCircularBuffer(10)
+			for i := 0; i < offset; i++ {
+				cb.Add("x")
+			}
+			for cb.Size() > 0 {
+				cb.Remove()
+			}
+			for i := 0; i < size; i++ {
+				cb.Add(numbers[i])
+			}
+			sink := cb.Drain(make([]interface{}, 0, 10))
+			if len(sink) != size {
+				t.Fatalf("Drain() at offset %d returned %d elements, expected %d", offset, len(sink), size)
+			}
+			if !cb.Empty() || cb.Remaining() != 10 {
+				t.Error("buffer should be empty after Drain()")
+			}
+			for i, v := range sink {
+				if v.(string) != numbers[i] {
+					t.Errorf("offset %d: expected %s from Drain() at %d, got %s", offset, numbers[i], i, v)
+				}
+			}
+			// the buffer remains usable after being drained
+			cb.Add("eleven")
+			if cb.Remove() != "eleven" {
+				t.Error("buffer unusable after Drain()")
+			}
 		}
 	}
 }
@@ -302,6 +296,33 @@ func TestCircularBufferMoveSmallSink(t *testing.T) {
 	}
 }
 
+func TestCircularBufferMovePartial(t *testing.T) {
+	source := NewCircularBuffer(10)
+	for i := 0; i < 10; i++ {
+		source.Add(numbers[i])
+	}
+	sink := NewCircularBuffer(10)
+	if count := source.Move(sink, 3); count != 3 {
+		t.Error("Move() returned unexpected count")
+	}
+	if sink.Size() != 3 || source.Size() != 7 {
+		t.Errorf("sizes after Move() are %d and %d, expected 3 and 7", sink.Size(), source.Size())
+	}
+	// the positions must agree with the counts
+	sink.Add("extra")
+	for i := 0; i < 3; i++ {
+		if e := sink.Remove(); e.(string) != numbers[i] {
+			t.Errorf("expected %s from Remove(), got %s", numbers[i], e)
+		}
+	}
+	if e := sink.Remove(); e.(string) != "extra" {
+		t.Errorf("expected extra from Remove(), got %s", e)
+	}
+	if e := source.Remove(); e.(string) != numbers[3] {
+		t.Errorf("expected %s from Remove(), got %s", numbers[3], e)
+	}
+}
+
 func TestCircularBufferMove(t *testing.T) {
 	source := NewCircularBuffer(10)
 	for i := 0; i < 10; i++ {
@@ -378,3 +399,291 @@ func TestCircularBufferMoveSinkWrap(t *testing.T) {
 		t.Error("sink should be empty now")
 	}
 }
+
+// The following tests exercise the typed RingBuffer directly, without
+// the type assertions needed by CircularBuffer.
+
+func TestRingBufferTyped(t *testing.T) {
+	rb := NewRingBuffer[string](10)
+	if rb.Capacity() != 10 || rb.Size() != 0 || !rb.Empty() {
+		t.Error("new typed buffer has unexpected state")
+	}
+	if rb.Peek() != "" || rb.Remove() != "" {
+		t.Error("Peek() and Remove() should return zero value for empty buffer")
+	}
+	// add and remove some elements so that the buffer wraps around
+	for i := 0; i < 5; i++ {
+		rb.Add(numbers[i])
+	}
+	for i := 0; i < 5; i++ {
+		if e := rb.Remove(); e != numbers[i] {
+			t.Errorf("expected %s from Remove(), got %s", numbers[i], e)
+		}
+	}
+	for i := 0; rb.Remaining() > 0; i++ {
+		rb.Add(numbers[i])
+	}
+	if rb.Add("eleven") {
+		t.Error("Add() should return false when full")
+	}
+	if !rb.Full() || rb.Remaining() != 0 {
+		t.Error("Full() should return true when Remaining() == 0")
+	}
+	if rb.Peek() != numbers[0] {
+		t.Error("Peek() returned incorrect element")
+	}
+	for i := 0; !rb.Empty(); i++ {
+		if e := rb.Remove(); e != numbers[i] {
+			t.Errorf("expected %s from Remove(), got %s", numbers[i], e)
+		}
+	}
+}
+
+func TestRingBufferTypedFromSlice(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+	rb := NewRingBufferFromSlice(input, false)
+	if rb.Size() != 4 || rb.Capacity() != 4 || !rb.Full() {
+		t.Error("buffer from slice has unexpected state")
+	}
+	for i := 1; !rb.Empty(); i++ {
+		if e := rb.Remove(); e != i {
+			t.Errorf("expected %d from Remove(), got %d", i, e)
+		}
+	}
+	// the buffer shares the slice with the caller
+	rb.Add(10)
+	if input[0] != 10 {
+		t.Error("buffer from slice should share the underlying slice")
+	}
+}
+
+func TestRingBufferTypedDrain(t *testing.T) {
+	rb := wrappedBuffer(10, 10)
+	sink := rb.Drain(nil)
+	if len(sink) != 10 {
+		t.Error("Drain() should return full contents")
+	}
+	if !rb.Empty() {
+		t.Error("Empty() should return true after Drain()")
+	}
+	if rb.Remaining() != 10 {
+		t.Error("Remaining() does not match available space")
+	}
+	for i, e := range sink {
+		if e != numbers[i] {
+			t.Errorf("expected %s from Drain() at %d, got %s", numbers[i], i, e)
+		}
+	}
+}
+
+func TestRingBufferFromSliceDupe(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+	rb := NewRingBufferFromSlice(input, true)
+	if rb.Size() != 4 || rb.Capacity() != 4 || !rb.Full() {
+		t.Error("buffer from copied slice has unexpected state")
+	}
+	for i := 1; !rb.Empty(); i++ {
+		if e := rb.Remove(); e != i {
+			t.Errorf("expected %d from Remove(), got %d", i, e)
+		}
+	}
+	// the buffer does not share the slice with the caller
+	rb.Add(10)
+	if input[0] != 1 {
+		t.Error("buffer from copied slice should not share the slice")
+	}
+	if rb.Remove() != 10 {
+		t.Error("value from Remove() does not match input")
+	}
+}
+
+func TestRingBufferSharedSlice(t *testing.T) {
+	// buffers that share a slice must stay within their own bounds
+	shared := make([]int, 8)
+	lower := NewRingBufferFromSlice(shared[:4], false)
+	upper := NewRingBufferFromSlice(shared[4:], false)
+	lower.RemoveN(4)
+	upper.RemoveN(4)
+	if lower.Capacity() != 4 || upper.Capacity() != 4 {
+		t.Fatal("capacity of buffers sharing a slice should be their length")
+	}
+	for i := 0; i < 6; i++ {
+		lower.Add(i)
+		upper.Add(i + 10)
+	}
+	if lower.Size() != 4 || upper.Size() != 4 {
+		t.Error("buffers sharing a slice accepted too many elements")
+	}
+	for i := 0; i < 4; i++ {
+		if e := lower.Remove(); e != i {
+			t.Errorf("expected %d from lower buffer, got %d", i, e)
+		}
+		if e := upper.Remove(); e != i+10 {
+			t.Errorf("expected %d from upper buffer, got %d", i+10, e)
+		}
+	}
+}
+
+func TestRingBufferTypedMove(t *testing.T) {
+	source := NewRingBuffer[string](10)
+	sink := NewRingBuffer[string](10)
+	// cause both buffers to wrap around during the move
+	for i := 0; i < 5; i++ {
+		source.Add(numbers[i])
+		sink.Add(numbers[i])
+	}
+	for i := 0; i < 5; i++ {
+		source.Remove()
+		sink.Remove()
+	}
+	for i := 0; i < 10; i++ {
+		source.Add(numbers[i])
+	}
+	if count := source.Move(sink, 10); count != 10 {
+		t.Error("Move() returned unexpected count")
+	}
+	for i := 0; !sink.Empty(); i++ {
+		if e := sink.Remove(); e != numbers[i] {
+			t.Errorf("expected %s from Remove(), got %s", numbers[i], e)
+		}
+	}
+	if !source.Empty() {
+		t.Error("source should be empty now")
+	}
+}
+
+// wrappedBuffer returns a buffer of the given capacity, holding the
+// first count numbers, with the contents wrapped around the end of the
+// underlying slice.
+func wrappedBuffer(capacity, count int) *RingBuffer[string] {
+	rb := NewRingBuffer[string](capacity)
+	for i := 0; i < capacity-2; i++ {
+		rb.Add("x")
+	}
+	for i := 0; i < capacity-2; i++ {
+		rb.Remove()
+	}
+	for i := 0; i < count; i++ {
+		rb.Add(numbers[i])
+	}
+	return rb
+}
+
+// checkContents verifies the buffer holds the first n numbers, in
+// order, using both At() and All().
+func checkContents(t *testing.T, rb *RingBuffer[string], n int) {
+	if rb.Size() != n {
+		t.Fatalf("expected %d elements, got %d", n, rb.Size())
+	}
+	for i := 0; i < n; i++ {
+		if e := rb.At(i); e != numbers[i] {
+			t.Errorf("expected %s from At(%d), got %s", numbers[i], i, e)
+		}
+	}
+	i := 0
+	for e := range rb.All() {
+		if e != numbers[i] {
+			t.Errorf("expected %s from All() at %d, got %s", numbers[i], i, e)
+		}
+		i++
+	}
+	if i != n {
+		t.Errorf("All() yielded %d elements, expected %d", i, n)
+	}
+}
+
+func TestRingBufferGrow(t *testing.T) {
+	rb := wrappedBuffer(5, 5)
+	if rb.Add(numbers[5]) {
+		t.Error("Add() should return false when full and not growable")
+	}
+	rb.SetAutoGrow(true)
+	for i := 5; i < len(numbers); i++ {
+		if !rb.Add(numbers[i]) {
+			t.Fatal("Add() should grow the buffer when full")
+		}
+	}
+	if rb.Capacity() < len(numbers) {
+		t.Errorf("capacity %d too small after growth", rb.Capacity())
+	}
+	checkContents(t, rb, len(numbers))
+	// an empty buffer grows as well
+	rb = NewRingBuffer[string](0)
+	rb.SetAutoGrow(true)
+	if !rb.Add(numbers[0]) {
+		t.Error("Add() should grow an empty buffer")
+	}
+	checkContents(t, rb, 1)
+}
+
+func TestRingBufferAt(t *testing.T) {
+	rb := wrappedBuffer(6, 5)
+	checkContents(t, rb, 5)
+	defer func() {
+		if recover() == nil {
+			t.Error("At() should panic when out of range")
+		}
+	}()
+	rb.At(5)
+}
+
+func TestRingBufferAllBreak(t *testing.T) {
+	rb := wrappedBuffer(6, 6)
+	var seen []string
+	for e := range rb.All() {
+		if e == numbers[3] {
+			break
+		}
+		seen = append(seen, e)
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected 3 elements before break, got %d", len(seen))
+	}
+	if rb.Size() != 6 {
+		t.Error("All() should not remove elements")
+	}
+}
+
+func TestRingBufferAddAll(t *testing.T) {
+	rb := wrappedBuffer(8, 2)
+	// fills the buffer, wrapping around the end of the slice
+	if n := rb.AddAll(numbers[2:]); n != 6 {
+		t.Errorf("AddAll() should add as many as fit, added %d", n)
+	}
+	checkContents(t, rb, 8)
+	if n := rb.AddAll(numbers); n != 0 {
+		t.Error("AddAll() should add nothing to a full buffer")
+	}
+	rb.SetAutoGrow(true)
+	rb.RemoveN(8)
+	rb.AddAll(numbers[:4])
+	if n := rb.AddAll(numbers[4:]); n != 6 {
+		t.Errorf("AddAll() should grow the buffer, added %d", n)
+	}
+	checkContents(t, rb, len(numbers))
+}
+
+func TestRingBufferRemoveN(t *testing.T) {
+	rb := wrappedBuffer(8, 8)
+	removed := rb.RemoveN(5)
+	if len(removed) != 5 {
+		t.Fatalf("RemoveN() returned %d elements, expected 5", len(removed))
+	}
+	for i, e := range removed {
+		if e != numbers[i] {
+			t.Errorf("expected %s from RemoveN() at %d, got %s", numbers[i], i, e)
+		}
+	}
+	if rb.Size() != 3 || rb.Peek() != numbers[5] {
+		t.Error("RemoveN() left the buffer in an unexpected state")
+	}
+	if removed = rb.RemoveN(10); len(removed) != 3 || !rb.Empty() {
+		t.Error("RemoveN() should remove no more than the buffer holds")
+	}
+	if removed = rb.RemoveN(1); len(removed) != 0 {
+		t.Error("RemoveN() on empty buffer should return nothing")
+	}
+	// the buffer remains usable after bulk removal
+	rb.AddAll(numbers[:8])
+	checkContents(t, rb, 8)
+}
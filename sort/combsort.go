@@ -39,3 +39,32 @@ func CombSort(input []string) {
 		}
 	}
 }
+
+// CombSortFunc sorts the slice using the comb sort, as with CombSort,
+// ordering the elements by the given less function. The comparisons
+// are made in the same order as by CombSort, such that for strings,
+// less(x, y) returning x < y yields identical behavior.
+func CombSortFunc[T any](input []T, less func(x, y T) bool) {
+	size := len(input)
+	if input == nil || size < 2 {
+		return
+	}
+
+	gap := size
+	swapped := true
+	for gap > 1 || swapped {
+		if gap > 1 {
+			gap = int(float32(gap) / 1.3)
+			if gap == 10 || gap == 9 {
+				gap = 11
+			}
+		}
+		swapped = false
+		for i := 0; i+gap < size; i++ {
+			if less(input[i+gap], input[i]) {
+				input[i], input[i+gap] = input[i+gap], input[i]
+				swapped = true
+			}
+		}
+	}
+}
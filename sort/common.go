@@ -6,6 +6,10 @@
 
 package sort
 
+import (
+	"strings"
+)
+
 // charAt retrieves the character in string s at offset d. If d is
 // greater than or equal to the length of the string, return zero.
 // This simulates fixed-length strings that are zero-padded.
@@ -16,6 +20,18 @@ func charAt(s string, d int) uint8 {
 	return 0
 }
 
+// hasNull returns true if any of the strings contains a null character,
+// which the sorts that examine strings a character at a time take as
+// the end of the string.
+func hasNull(strs []string) bool {
+	for _, s := range strs {
+		if strings.IndexByte(s, nullterm) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // iMax returns the maximum of x and y.
 func iMax(x, y int) int {
 	if x < y {
@@ -157,3 +157,157 @@ func dualPivotQuicksort(a []string, left int, right int) {
 		dualPivotQuicksort(a, less, great)
 	}
 }
+
+// DualPivotQuickSortFunc sorts the slice using the dual pivot quicksort,
+// as with DualPivotQuickSort, ordering the elements by the given less
+// function. The outcome of the comparisons matches that of
+// DualPivotQuickSort, such that for strings, less(x, y) returning
+// x < y yields identical behavior; tests for equality are made with
+// two calls to less.
+func DualPivotQuickSortFunc[T any](a []T, less func(x, y T) bool) {
+	size := len(a)
+	if a == nil || size < 2 {
+		return
+	}
+	dualPivotQuicksortFunc(a, 0, size-1, less)
+}
+
+// dualPivotQuicksortFunc is identical to dualPivotQuicksort except that
+// elements are compared using the less function.
+func dualPivotQuicksortFunc[T any](a []T, left int, right int, less func(x, y T) bool) {
+	len := right - left
+
+	// perform insertion sort on small ranges
+	if len < 17 {
+		for i := left + 1; i <= right; i++ {
+			for j := i; j > left && less(a[j], a[j-1]); j-- {
+				a[j-1], a[j] = a[j], a[j-1]
+			}
+		}
+		return
+	}
+
+	// compute indices of medians
+	sixth := len / 6
+	m1 := left + sixth
+	m2 := m1 + sixth
+	m3 := m2 + sixth
+	m4 := m3 + sixth
+	m5 := m4 + sixth
+
+	// order the medians in preparation for partitioning
+	if less(a[m2], a[m1]) {
+		a[m1], a[m2] = a[m2], a[m1]
+	}
+	if less(a[m5], a[m4]) {
+		a[m4], a[m5] = a[m5], a[m4]
+	}
+	if less(a[m3], a[m1]) {
+		a[m1], a[m3] = a[m3], a[m1]
+	}
+	if less(a[m3], a[m2]) {
+		a[m2], a[m3] = a[m3], a[m2]
+	}
+	if less(a[m4], a[m1]) {
+		a[m1], a[m4] = a[m4], a[m1]
+	}
+	if less(a[m4], a[m3]) {
+		a[m3], a[m4] = a[m4], a[m3]
+	}
+	if less(a[m5], a[m2]) {
+		a[m2], a[m5] = a[m5], a[m2]
+	}
+	if less(a[m3], a[m2]) {
+		a[m2], a[m3] = a[m3], a[m2]
+	}
+	if less(a[m5], a[m4]) {
+		a[m4], a[m5] = a[m5], a[m4]
+	}
+
+	// select the pivots such that [ < pivot1 | pivot1 <= && <= pivot2 | > pivot2 ]
+	pivot1 := a[m2]
+	pivot2 := a[m4]
+
+	diffPivots := less(pivot1, pivot2) || less(pivot2, pivot1)
+
+	// move the pivots out of the away
+	a[m2] = a[left]
+	a[m4] = a[right]
+	lower := left + 1
+	great := right - 1
+
+	// partition the elements
+	if diffPivots {
+		for k := lower; k <= great; k++ {
+			x := a[k]
+			if less(pivot2, x) {
+				for less(pivot2, a[great]) && k < great {
+					great--
+				}
+				a[k] = a[great]
+				a[great] = x
+				great--
+				x = a[k]
+			}
+			if less(x, pivot1) {
+				a[k] = a[lower]
+				a[lower] = x
+				lower++
+			}
+		}
+	} else {
+		for k := lower; k <= great; k++ {
+			x := a[k]
+			if !less(x, pivot1) && !less(pivot1, x) {
+				continue
+			}
+			if less(pivot1, x) {
+				for less(pivot2, a[great]) && k < great {
+					great--
+				}
+				a[k] = a[great]
+				a[great] = x
+				great--
+				x = a[k]
+			}
+			if less(x, pivot1) {
+				a[k] = a[lower]
+				a[lower] = x
+				lower++
+			}
+		}
+	}
+
+	// swap the pivots back into position
+	a[left] = a[lower-1]
+	a[lower-1] = pivot1
+	a[right] = a[great+1]
+	a[great+1] = pivot2
+
+	// recursively sort the left and right partitions
+	dualPivotQuicksortFunc(a, left, lower-2, less)
+	dualPivotQuicksortFunc(a, great+2, right, less)
+
+	// order the equal elements in the middle
+	if great-lower > len-13 && diffPivots {
+		for k := lower; k <= great; k++ {
+			x := a[k]
+			if !less(x, pivot2) && !less(pivot2, x) {
+				a[k] = a[great]
+				a[great] = x
+				great--
+				x = a[k]
+			}
+			if !less(x, pivot1) && !less(pivot1, x) {
+				a[k] = a[lower]
+				a[lower] = x
+				lower++
+			}
+		}
+	}
+
+	// recursively sort the middle partition
+	if diffPivots {
+		dualPivotQuicksortFunc(a, lower, great, less)
+	}
+}
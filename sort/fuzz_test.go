@@ -0,0 +1,217 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// This file contains a fuzz harness shared by all of the string sorts.
+// To cover a new sort, add it to the sortAlgorithms table. The seed
+// corpus runs as part of the regular tests; to fuzz, run something like
+//
+//	go test -run XXX -fuzz FuzzSorts -fuzzminimizetime 5s ./sort
+//
+// where limiting the minimization time keeps the fuzzer from stalling
+// on the larger inputs when few processors are available.
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/nlfiedler/sortingo/internal/generate"
+)
+
+// sortAlgorithm describes a sort exercised by the fuzz harness.
+type sortAlgorithm struct {
+	name   string         // name used in failure messages
+	sort   func([]string) // sorts the slice in place
+	stable bool           // true if equal strings retain their order
+	max    int            // maximum input size, or zero for no limit
+}
+
+// quadraticLimit is the largest input given to the O(n^2) sorts.
+const quadraticLimit = 4096
+
+// sortAlgorithms lists every sort that is checked by the fuzz harness.
+var sortAlgorithms = []sortAlgorithm{
+	{"BinaryInsertionSort", BinaryInsertionSort, true, quadraticLimit},
+	{"BurstSort", BurstSort, false, 0},
+	{"CombSort", CombSort, false, 0},
+	{"DualPivotQuickSort", DualPivotQuickSort, false, 0},
+	{"GnomeSort", GnomeSort, true, quadraticLimit},
+	{"HeapSort", HeapSort, false, 0},
+	{"HybridCombSort", HybridCombSort, false, 0},
+	{"InsertionSort", InsertionSort, true, quadraticLimit},
+	{"IntroSort", IntroSort, false, 0},
+	{"MergeSort", MergeSort, true, 0},
+	{"MultikeyQuickSort", MultikeyQuickSort, false, 0},
+	{"SelectionSort", SelectionSort, false, quadraticLimit},
+	{"ShellSort", ShellSort, false, 0},
+	{"BurstSortIndex", permutationSort(BurstSortIndex), false, 0},
+	{"IntroSortIndex", permutationSort(IntroSortIndex), false, 0},
+	{"MultikeyQuickSortIndex", permutationSort(MultikeyQuickSortIndex), false, 0},
+	{"IntroSortFunc", func(a []string) { IntroSortFunc(a, stringLess) }, false, 0},
+	{"DualPivotQuickSortFunc", func(a []string) { DualPivotQuickSortFunc(a, stringLess) }, false, 0},
+	{"HeapSortFunc", func(a []string) { HeapSortFunc(a, stringLess) }, false, 0},
+	{"ShellSortFunc", func(a []string) { ShellSortFunc(a, stringLess) }, false, 0},
+	{"MergeSortFunc", func(a []string) { MergeSortFunc(a, stringLess) }, true, 0},
+	{"CombSortFunc", func(a []string) { CombSortFunc(a, stringLess) }, false, 0},
+	{"HybridCombSortFunc", func(a []string) { HybridCombSortFunc(a, stringLess) }, false, 0},
+}
+
+// stringLess orders strings for the sorts that take a less function.
+func stringLess(x, y string) bool {
+	return x < y
+}
+
+// permutationSort adapts a sort that returns a permutation into one
+// that sorts in place; ApplyPermutation panics if it is invalid.
+func permutationSort(f func([]string) []int) func([]string) {
+	return func(a []string) {
+		ApplyPermutation(f(a), a)
+	}
+}
+
+// fuzzInput splits the fuzzer data into strings on newlines. The string
+// sorts treat the null character as a terminator, so any null bytes are
+// removed. All of the strings share the backing array of the data, in
+// order, which allows the harness to detect the reordering of equal
+// strings.
+func fuzzInput(data string) []string {
+	data = strings.ReplaceAll(data, "\x00", "")
+	if data == "" {
+		return nil
+	}
+	return strings.Split(data, "\n")
+}
+
+// checkSortResult verifies that output is the sorted form of input,
+// and for stable sorts, that equal strings retain their original order.
+// On failure, the sort is run on ever smaller parts of the input to
+// find a minimal input on which it fails.
+func checkSortResult(t *testing.T, alg sortAlgorithm, input, output []string) {
+	t.Helper()
+	err := sortResultError(alg, input, output)
+	if err == nil {
+		return
+	}
+	minimal := minimizeInput(input, func(input []string) bool {
+		return sortFails(alg.sort, input, func(input, output []string) bool {
+			return sortResultError(alg, input, output) != nil
+		})
+	})
+	t.Fatalf("%s: %v; minimized failing input: %s", alg.name, err, formatInput(minimal))
+}
+
+// sortResultError describes the first way in which the output is not
+// the correctly sorted form of the input, or returns nil.
+func sortResultError(alg sortAlgorithm, input, output []string) error {
+	// the output must agree with the standard library
+	expected := make([]string, len(input))
+	copy(expected, input)
+	sort.Strings(expected)
+	if len(output) != len(expected) {
+		return fmt.Errorf("output length %d != input length %d", len(output), len(expected))
+	}
+	for i := 1; i < len(output); i++ {
+		if output[i-1] > output[i] {
+			return fmt.Errorf("output not sorted at %d: %q > %q", i, output[i-1], output[i])
+		}
+	}
+	// the output must be a permutation of the input
+	counts := make(map[string]int)
+	for _, s := range input {
+		counts[s]++
+	}
+	for _, s := range output {
+		counts[s]--
+		if counts[s] < 0 {
+			return fmt.Errorf("output contains extra copies of %q", s)
+		}
+	}
+	for i, s := range output {
+		if s != expected[i] {
+			return fmt.Errorf("output differs from sort.Strings at %d: %q != %q", i, s, expected[i])
+		}
+	}
+	if alg.stable {
+		// equal, non-empty strings must appear in order of their
+		// position within the backing array
+		for i := 1; i < len(output); i++ {
+			a, b := output[i-1], output[i]
+			if a == b && a != "" && uintptr(unsafe.Pointer(unsafe.StringData(a))) > uintptr(unsafe.Pointer(unsafe.StringData(b))) {
+				return fmt.Errorf("equal strings %q reordered at %d", a, i)
+			}
+		}
+	}
+	return nil
+}
+
+// fuzzSeedSize is the number of strings in each seed taken from the
+// test data sets; larger seeds greatly slow the minimization of inputs
+// by the fuzzing engine.
+const fuzzSeedSize = 64
+
+// addFuzzSeeds adds a variety of inputs from the test data sets to the
+// fuzzing corpus.
+func addFuzzSeeds(f *testing.F) {
+	f.Add("")
+	f.Add("a")
+	f.Add("b\na")
+	f.Add("z\nm\n\na\nd\ntt\ntt\ntt\nfoo\nbar")
+	f.Add("a\nab\nabc\nab\na\n\nabc\nb")
+	f.Add(strings.Repeat("x\n", 100))
+	for _, data := range [][]string{repeatedCycleStrings, nonUniqueWords, uniqueWords, smallAlphaStrings, genomeStrings} {
+		f.Add(strings.Join(data[:fuzzSeedSize], "\n"))
+	}
+	// generated text with long prefixes and multi-byte characters
+	r := rand.New(rand.NewSource(1))
+	for _, name := range []string{"Zipf", "URLs", "Paths", "UTF8", "DNAReads"} {
+		f.Add(strings.Join(generate.Generators[name](r, fuzzSeedSize), "\n"))
+	}
+	reversed := make([]string, fuzzSeedSize)
+	copy(reversed, uniqueWords)
+	sort.Sort(sort.Reverse(sort.StringSlice(reversed)))
+	f.Add(strings.Join(reversed, "\n"))
+}
+
+func FuzzSorts(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data string) {
+		input := fuzzInput(data)
+		output := make([]string, len(input))
+		for _, alg := range sortAlgorithms {
+			if alg.max > 0 && len(input) > alg.max {
+				continue
+			}
+			copy(output, input)
+			alg.sort(output)
+			checkSortResult(t, alg, input, output)
+		}
+	})
+}
+
+// generatedTestSize is the number of strings in each generated data set
+// sorted by TestSortsGenerated.
+const generatedTestSize = 2000
+
+func TestSortsGenerated(t *testing.T) {
+	r := newTestRand(t)
+	for _, name := range generate.Names {
+		// sharing the backing array of a single string allows the
+		// stability of the sorts to be checked
+		input := fuzzInput(strings.Join(generate.Generators[name](r, generatedTestSize), "\n"))
+		output := make([]string, len(input))
+		for _, alg := range sortAlgorithms {
+			copy(output, input)
+			alg.sort(output)
+			alg.name += " on " + name
+			checkSortResult(t, alg, input, output)
+		}
+	}
+}
@@ -71,3 +71,39 @@ func HeapSort(input []string) {
 		// the previous max value will stay in its proper placement
 	}
 }
+
+// HeapSortFunc sorts the slice using the heap sort, as with HeapSort,
+// ordering the elements by the given less function. The comparisons
+// are made in the same order as by HeapSort, such that for strings,
+// less(x, y) returning x < y yields identical behavior.
+func HeapSortFunc[T any](input []T, less func(x, y T) bool) {
+	size := len(input)
+	if input == nil || size < 2 {
+		return
+	}
+
+	for start := (size - 2) / 2; start >= 0; start-- {
+		heapSiftDownFunc(input, start, size, less)
+	}
+	for end := size - 1; end > 0; end-- {
+		input[0], input[end] = input[end], input[0]
+		heapSiftDownFunc(input, 0, end, less)
+	}
+}
+
+// heapSiftDownFunc sifts the node at root down the heap of the first
+// size elements, as in the loops of HeapSort, comparing the elements
+// using the less function.
+func heapSiftDownFunc[T any](input []T, root, size int, less func(x, y T) bool) {
+	for root*2+1 < size {
+		child := root*2 + 1
+		if child+1 < size && less(input[child], input[child+1]) {
+			child++
+		}
+		if !less(input[root], input[child]) {
+			return
+		}
+		input[root], input[child] = input[child], input[root]
+		root = child
+	}
+}
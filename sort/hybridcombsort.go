@@ -35,3 +35,30 @@ func HybridCombSort(a []string) {
 	// insertion sort performs very well.
 	InsertionSort(a)
 }
+
+// HybridCombSortFunc sorts the slice using the hybrid comb sort, as
+// with HybridCombSort, ordering the elements by the given less
+// function. The comparisons are made in the same order as by
+// HybridCombSort, such that for strings, less(x, y) returning x < y
+// yields identical behavior.
+func HybridCombSortFunc[T any](a []T, less func(x, y T) bool) {
+	size := len(a)
+	if a == nil || size < 2 {
+		return
+	}
+
+	gap := size
+	for gap > 8 {
+		gap = (10 * gap) / 13
+		if gap == 10 || gap == 9 {
+			gap = 11
+		}
+		for i := 0; i+gap < size; i++ {
+			j := i + gap
+			if less(a[j], a[i]) {
+				a[i], a[j] = a[j], a[i]
+			}
+		}
+	}
+	insertionsortFunc(0, size, a, less)
+}
@@ -52,7 +52,7 @@ func introsortLoop(low, high, depth_limit int, a []string) {
 			}
 			for i := n; i > 1; i-- {
 				a[low], a[low+i-1] = a[low+i-1], a[low]
-				d := a[low+i-1]
+				d := a[low]
 				j := 1
 				m := i - 1
 				for j <= m/2 {
@@ -138,3 +138,124 @@ func insertionsort(low, high int, a []string) {
 		a[j] = t
 	}
 }
+
+// IntroSortFunc sorts the slice using the introspective sort, as with
+// IntroSort, ordering the elements by the given less function. The
+// sequence of comparisons matches that of IntroSort exactly, such that
+// for strings, less(x, y) returning x < y yields identical behavior.
+func IntroSortFunc[T any](a []T, less func(x, y T) bool) {
+	size := len(a)
+	if a == nil || size < 2 {
+		return
+	}
+	floor := int(math.Floor(math.Log2(float64(size))))
+	introsortLoopFunc(0, size, 2*floor, a, less)
+	insertionsortFunc(0, size, a, less)
+}
+
+// introsortLoopFunc is identical to introsortLoop except that elements
+// are compared using the less function.
+func introsortLoopFunc[T any](low, high, depth_limit int, a []T, less func(x, y T) bool) {
+	for high-low > 16 {
+		if depth_limit == 0 {
+			// perform a basic heap sort
+			n := high - low
+			for i := n / 2; i >= 1; i-- {
+				d := a[low+i-1]
+				j := i
+				for j <= n/2 {
+					child := 2 * j
+					if child < n && less(a[low+child-1], a[low+child]) {
+						child++
+					}
+					if !less(d, a[low+child-1]) {
+						break
+					}
+					a[low+j-1] = a[low+child-1]
+					j = child
+				}
+				a[low+j-1] = d
+			}
+			for i := n; i > 1; i-- {
+				a[low], a[low+i-1] = a[low+i-1], a[low]
+				d := a[low]
+				j := 1
+				m := i - 1
+				for j <= m/2 {
+					child := 2 * j
+					if child < m && less(a[low+child-1], a[low+child]) {
+						child++
+					}
+					if !less(d, a[low+child-1]) {
+						break
+					}
+					a[low+j-1] = a[low+child-1]
+					j = child
+				}
+				a[low+j-1] = d
+			}
+			return
+		}
+		depth_limit--
+		x := introsortMedianFunc(low, low+((high-low)/2)+1, high-1, a, less)
+		p := introsortPartitionFunc(low, high, x, a, less)
+		introsortLoopFunc(p, high, depth_limit, a, less)
+		high = p
+	}
+}
+
+// introsortPartitionFunc is identical to introsortPartition except
+// that elements are compared using the less function.
+func introsortPartitionFunc[T any](low, high int, x T, a []T, less func(x, y T) bool) int {
+	i := low
+	j := high
+	for {
+		for less(a[i], x) {
+			i++
+		}
+		j--
+		for less(x, a[j]) {
+			j--
+		}
+		if i >= j {
+			return i
+		}
+		a[i], a[j] = a[j], a[i]
+		i++
+	}
+}
+
+// introsortMedianFunc is identical to introsortMedian except that
+// elements are compared using the less function.
+func introsortMedianFunc[T any](low, mid, high int, a []T, less func(x, y T) bool) T {
+	if less(a[mid], a[low]) {
+		if less(a[high], a[mid]) {
+			return a[mid]
+		}
+		if less(a[high], a[low]) {
+			return a[high]
+		}
+		return a[low]
+	}
+	if less(a[high], a[mid]) {
+		if less(a[high], a[low]) {
+			return a[low]
+		}
+		return a[high]
+	}
+	return a[mid]
+}
+
+// insertionsortFunc is identical to insertionsort except that elements
+// are compared using the less function.
+func insertionsortFunc[T any](low, high int, a []T, less func(x, y T) bool) {
+	for i := low; i < high; i++ {
+		j := i
+		t := a[i]
+		for j != low && less(t, a[j-1]) {
+			a[j] = a[j-1]
+			j--
+		}
+		a[j] = t
+	}
+}
@@ -7,6 +7,7 @@
 package sort
 
 import (
+	"sort"
 	"testing"
 )
 
@@ -19,3 +20,26 @@ func TestIntroSort(t *testing.T) {
 	testSortReversed(t, IntroSort, mediumDataSize)
 	testSortNonUnique(t, IntroSort, mediumDataSize)
 }
+
+// TestIntroSortHeapFallback sorts an input that drives the quicksort
+// phase past its depth limit, and verifies that the heap sort fallback
+// neither loses nor duplicates any elements.
+func TestIntroSortHeapFallback(t *testing.T) {
+	input := make([]string, mediumDataSize)
+	copy(input, uniqueWords)
+	sort.Strings(input)
+	// move the largest element to the middle to force the unbalanced
+	// partitioning that exhausts the depth limit
+	n := len(input) - 1
+	input[n/2] = input[n]
+	input = input[:n]
+	expected := make([]string, n)
+	copy(expected, input)
+	sort.Strings(expected)
+	IntroSort(input)
+	for i := range expected {
+		if input[i] != expected[i] {
+			t.Fatalf("expected %q at %d, got %q", expected[i], i, input[i])
+		}
+	}
+}
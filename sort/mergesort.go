@@ -51,3 +51,48 @@ func MergeSort(a []string) {
 	// copy into the original array
 	copy(a, result)
 }
+
+// MergeSortFunc sorts the slice using the merge sort, as with
+// MergeSort, ordering the elements by the given less function. The
+// sort is stable, and the comparisons are made in the same order as by
+// MergeSort, such that for strings, less(x, y) returning x < y yields
+// identical behavior.
+func MergeSortFunc[T any](a []T, less func(x, y T) bool) {
+	size := len(a)
+	if a == nil || size < 2 {
+		return
+	}
+
+	// for small sets, delegate to insertion sort
+	if size < 7 {
+		insertionsortFunc(0, size, a, less)
+		return
+	}
+
+	middle := size / 2
+	left := a[:middle]
+	right := a[middle:]
+	MergeSortFunc(left, less)
+	MergeSortFunc(right, less)
+
+	result := make([]T, 0, size)
+	li := 0
+	ls := len(left)
+	ri := 0
+	rs := len(right)
+	for li < ls && ri < rs {
+		if !less(right[ri], left[li]) {
+			result = append(result, left[li])
+			li++
+		} else {
+			result = append(result, right[ri])
+			ri++
+		}
+	}
+	if li < ls {
+		result = append(result, left[li:]...)
+	} else if ri < rs {
+		result = append(result, right[ri:]...)
+	}
+	copy(a, result)
+}
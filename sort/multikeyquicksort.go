@@ -30,6 +30,28 @@ func MultikeyQuickSortDepth(a []string, depth int) {
 		return
 	}
 
+	lo, hi, allzeros := multikeyPartition(a, depth)
+	if lo > 1 {
+		MultikeyQuickSortDepth(a[:lo], depth)
+	}
+	if !allzeros {
+		// Only descend if there was at least one string that was
+		// of equal or greater length than current depth.
+		MultikeyQuickSortDepth(a[lo:hi], depth+1)
+	}
+	if n-hi > 1 {
+		MultikeyQuickSortDepth(a[hi:], depth)
+	}
+}
+
+// multikeyPartition performs the three-way partitioning step of the
+// multikey quicksort, using the character at the given depth. Upon
+// return, a[:lo] holds the strings whose character is less than that
+// of the pivot, a[lo:hi] those that are equal, and a[hi:] those that
+// are greater. If allzeros is true, every string in a[lo:hi] ends at
+// (or before) the given depth.
+func multikeyPartition(a []string, depth int) (lo, hi int, allzeros bool) {
+	n := len(a)
 	// Find the median of three to determine our pivot value.
 	pl := 0
 	pm := n / 2
@@ -48,7 +70,7 @@ func MultikeyQuickSortDepth(a []string, depth int) {
 	a[0], a[pm] = a[pm], a[0]
 
 	v := int(charAt(a[0], depth))
-	var allzeros bool = v == 0
+	allzeros = v == 0
 	le, lt := 1, 1
 	gt := n - 1
 	ge := gt
@@ -91,19 +113,9 @@ func MultikeyQuickSortDepth(a []string, depth int) {
 	vecswap(a, 0, lt-r, r)
 	r = iMin(ge-gt, pn-ge-1)
 	vecswap(a, lt, pn-r, r)
-	r = lt - le
-	if r > 1 {
-		MultikeyQuickSortDepth(a[:r], depth)
-	}
-	if !allzeros {
-		// Only descend if there was at least one string that was
-		// of equal or greater length than current depth.
-		MultikeyQuickSortDepth(a[r:r+le+n-ge-1], depth+1)
-	}
-	r = ge - gt
-	if r > 1 {
-		MultikeyQuickSortDepth(a[n-r:], depth)
-	}
+	lo = lt - le
+	hi = lo + le + n - ge - 1
+	return
 }
 
 // Swap the elements between to areas within a slice.
@@ -0,0 +1,237 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// Radix sorts for fixed-width numeric keys. Each of the supported types
+// is mapped to an unsigned 64-bit key whose unsigned order matches the
+// numeric order of the original values, and those keys are then sorted
+// one byte at a time, either from the least significant byte (LSD) or
+// from the most significant byte (MSD).
+
+import (
+	"math"
+)
+
+// radixBits is the number of bits considered in each radix pass.
+const radixBits = 8
+
+// radixSize is the number of buckets for each radix pass.
+const radixSize = 1 << radixBits
+
+// signBit is the most significant bit of a 64-bit key.
+const signBit = 1 << 63
+
+// RadixSortUint64 sorts the slice of unsigned integers using a least
+// significant digit radix sort, with O(n) running time and O(n) extra
+// space.
+func RadixSortUint64(a []uint64) {
+	if len(a) < 2 {
+		return
+	}
+	lsdRadixSort(a, make([]uint64, len(a)))
+}
+
+// RadixSortInt64 sorts the slice of signed integers using a least
+// significant digit radix sort, with O(n) running time and O(n) extra
+// space.
+func RadixSortInt64(a []int64) {
+	sortInt64Keys(a, func(keys []uint64) {
+		lsdRadixSort(keys, make([]uint64, len(keys)))
+	})
+}
+
+// RadixSortFloat64 sorts the slice of floating point numbers using a
+// least significant digit radix sort, with O(n) running time and O(n)
+// extra space. NaN values are placed before all other values, and
+// negative zero is placed before positive zero.
+func RadixSortFloat64(a []float64) {
+	sortFloat64Keys(a, func(keys []uint64) {
+		lsdRadixSort(keys, make([]uint64, len(keys)))
+	})
+}
+
+// MSDRadixSortUint64 sorts the slice of unsigned integers in place
+// using a most significant digit radix sort (American flag sort). For
+// very small partitions, a simple insertion sort is used.
+func MSDRadixSortUint64(a []uint64) {
+	if len(a) < 2 {
+		return
+	}
+	msdRadixSort(a, 64-radixBits)
+}
+
+// MSDRadixSortInt64 sorts the slice of signed integers using a most
+// significant digit radix sort, as with MSDRadixSortUint64.
+func MSDRadixSortInt64(a []int64) {
+	sortInt64Keys(a, func(keys []uint64) {
+		msdRadixSort(keys, 64-radixBits)
+	})
+}
+
+// MSDRadixSortFloat64 sorts the slice of floating point numbers using
+// a most significant digit radix sort, as with MSDRadixSortUint64. NaN
+// values are placed before all other values, and negative zero is
+// placed before positive zero.
+func MSDRadixSortFloat64(a []float64) {
+	sortFloat64Keys(a, func(keys []uint64) {
+		msdRadixSort(keys, 64-radixBits)
+	})
+}
+
+// sortInt64Keys converts the signed integers to unsigned keys by
+// flipping the sign bit, sorts the keys with the given function, and
+// converts them back again.
+func sortInt64Keys(a []int64, f func([]uint64)) {
+	if len(a) < 2 {
+		return
+	}
+	keys := make([]uint64, len(a))
+	for i, v := range a {
+		keys[i] = uint64(v) ^ signBit
+	}
+	f(keys)
+	for i, k := range keys {
+		a[i] = int64(k ^ signBit)
+	}
+}
+
+// sortFloat64Keys moves any NaN values to the front of the slice, then
+// converts the remaining values to unsigned keys such that negative
+// values (whose bits are all flipped) order before positive values
+// (whose sign bit is flipped). The keys are sorted with the given
+// function and then converted back again.
+func sortFloat64Keys(a []float64, f func([]uint64)) {
+	nans := 0
+	for i, v := range a {
+		if math.IsNaN(v) {
+			a[nans], a[i] = a[i], a[nans]
+			nans++
+		}
+	}
+	a = a[nans:]
+	if len(a) < 2 {
+		return
+	}
+	keys := make([]uint64, len(a))
+	for i, v := range a {
+		b := math.Float64bits(v)
+		if b&signBit != 0 {
+			keys[i] = ^b
+		} else {
+			keys[i] = b | signBit
+		}
+	}
+	f(keys)
+	for i, k := range keys {
+		if k&signBit != 0 {
+			a[i] = math.Float64frombits(k &^ signBit)
+		} else {
+			a[i] = math.Float64frombits(^k)
+		}
+	}
+}
+
+// lsdRadixSort sorts the keys one byte at a time, starting with the
+// least significant byte, using buf as scratch space of equal length.
+// Passes in which every key has the same byte value are skipped.
+func lsdRadixSort(keys, buf []uint64) {
+	n := len(keys)
+	if n < insertionThreshold {
+		insertionSortUint64(keys)
+		return
+	}
+	// Count the occurrences of every byte value for all passes at once.
+	var counts [64 / radixBits][radixSize]int
+	for _, k := range keys {
+		for p := range counts {
+			counts[p][uint8(k>>(uint(p)*radixBits))]++
+		}
+	}
+	src, dst := keys, buf
+	for p := range counts {
+		shift := uint(p) * radixBits
+		count := &counts[p]
+		if count[uint8(src[0]>>shift)] == n {
+			// all keys share this byte, nothing to do
+			continue
+		}
+		// Convert the counts into starting offsets.
+		offset := 0
+		for c := range count {
+			offset, count[c] = offset+count[c], offset
+		}
+		for _, k := range src {
+			c := uint8(k >> shift)
+			dst[count[c]] = k
+			count[c]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &keys[0] {
+		copy(keys, src)
+	}
+}
+
+// msdRadixSort sorts the keys in place by the byte at the given shift
+// offset, then recursively sorts each bucket by the next lower byte.
+func msdRadixSort(keys []uint64, shift uint) {
+	n := len(keys)
+	if n < insertionThreshold {
+		insertionSortUint64(keys)
+		return
+	}
+	var count [radixSize]int
+	for _, k := range keys {
+		count[uint8(k>>shift)]++
+	}
+	// Compute the start (head) and end (tail) of each bucket.
+	var head, tail [radixSize]int
+	offset := 0
+	for c := range count {
+		head[c] = offset
+		offset += count[c]
+		tail[c] = offset
+	}
+	// Permute the keys in place by cycling each misplaced key into
+	// the next free slot of its bucket.
+	for c := range count {
+		for head[c] < tail[c] {
+			k := keys[head[c]]
+			d := uint8(k >> shift)
+			for int(d) != c {
+				keys[head[d]], k = k, keys[head[d]]
+				head[d]++
+				d = uint8(k >> shift)
+			}
+			keys[head[c]] = k
+			head[c]++
+		}
+	}
+	if shift == 0 {
+		return
+	}
+	start := 0
+	for c := range count {
+		if count[c] > 1 {
+			msdRadixSort(keys[start:start+count[c]], shift-radixBits)
+		}
+		start += count[c]
+	}
+}
+
+// insertionSortUint64 sorts the keys using a simple insertion sort.
+func insertionSortUint64(keys []uint64) {
+	for i := 1; i < len(keys); i++ {
+		pivot := keys[i]
+		j := i
+		for j > 0 && pivot < keys[j-1] {
+			keys[j] = keys[j-1]
+			j--
+		}
+		keys[j] = pivot
+	}
+}
@@ -0,0 +1,97 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// radixTestSizes covers the insertion sort cutoff as well as inputs
+// large enough to require every radix pass.
+var radixTestSizes = []int{0, 1, 2, 15, 16, 17, 300, mediumDataSize}
+
+func TestRadixSortUint64(t *testing.T) {
+	r := newTestRand(t)
+	for _, f := range []func([]uint64){RadixSortUint64, MSDRadixSortUint64} {
+		for _, size := range radixTestSizes {
+			input := make([]uint64, size)
+			for i := range input {
+				input[i] = r.Uint64()
+				if i%3 == 0 {
+					// exercise the passes that can be skipped
+					input[i] >>= 40
+				}
+			}
+			f(input)
+			if !sort.SliceIsSorted(input, func(i, j int) bool { return input[i] < input[j] }) {
+				t.Errorf("uint64 input of size %d not sorted", size)
+			}
+		}
+		input := []uint64{math.MaxUint64, 0, 1, math.MaxUint64 - 1, 1 << 63}
+		f(input)
+		if input[0] != 0 || input[1] != 1 || input[2] != 1<<63 || input[4] != math.MaxUint64 {
+			t.Errorf("uint64 extremes not sorted: %v", input)
+		}
+	}
+}
+
+func TestRadixSortInt64(t *testing.T) {
+	r := newTestRand(t)
+	for _, f := range []func([]int64){RadixSortInt64, MSDRadixSortInt64} {
+		for _, size := range radixTestSizes {
+			input := make([]int64, size)
+			for i := range input {
+				input[i] = int64(r.Uint64())
+				if i%2 == 0 {
+					input[i] %= 1000
+				}
+			}
+			expected := make([]int64, size)
+			copy(expected, input)
+			sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+			f(input)
+			for i := range expected {
+				if input[i] != expected[i] {
+					t.Fatalf("int64 input of size %d not sorted at %d", size, i)
+				}
+			}
+		}
+	}
+}
+
+func TestRadixSortFloat64(t *testing.T) {
+	r := newTestRand(t)
+	for _, f := range []func([]float64){RadixSortFloat64, MSDRadixSortFloat64} {
+		for _, size := range radixTestSizes {
+			input := make([]float64, size)
+			for i := range input {
+				input[i] = r.NormFloat64() * 1e6
+			}
+			f(input)
+			if !sort.Float64sAreSorted(input) {
+				t.Errorf("float64 input of size %d not sorted", size)
+			}
+		}
+		negz := math.Copysign(0, -1)
+		input := []float64{1, math.Inf(1), 0, math.NaN(), -1, negz,
+			math.Inf(-1), math.SmallestNonzeroFloat64, math.NaN(), -math.MaxFloat64}
+		f(input)
+		if !math.IsNaN(input[0]) || !math.IsNaN(input[1]) {
+			t.Errorf("NaN values not placed first: %v", input)
+		}
+		expected := []float64{math.Inf(-1), -math.MaxFloat64, -1, negz, 0,
+			math.SmallestNonzeroFloat64, 1, math.Inf(1)}
+		for i, e := range expected {
+			v := input[i+2]
+			if v != e || math.Signbit(v) != math.Signbit(e) {
+				t.Errorf("expected %v at %d, got %v", e, i+2, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,288 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// Sorting of records (e.g. rows of a CSV file) by an ordered list of
+// keys. The keys are treated much like the characters of a string in
+// multikey quicksort: the records are partitioned three ways on the
+// first key, and those records that tie are then partitioned on the
+// next key, and so on until the keys are exhausted.
+
+import (
+	"math"
+	gosort "sort"
+	"strconv"
+	"strings"
+)
+
+// KeyType determines how the values of a record key are compared.
+type KeyType int
+
+const (
+	// StringKey compares values byte-wise, as with the string sorts.
+	StringKey KeyType = iota
+	// NumericKey compares values as floating point numbers. Values
+	// that cannot be parsed as numbers order before all numbers, or
+	// after them if the key is descending, and are considered equal
+	// to one another.
+	NumericKey
+	// NaturalKey compares values as strings, except that runs of
+	// digits are compared by their numeric value, such that "file9"
+	// orders before "file10".
+	NaturalKey
+)
+
+// RecordKey describes one key of a multi-key record sort.
+type RecordKey[T any] struct {
+	// Extract returns the value of the key for the given record.
+	Extract func(T) string
+	// Type determines how the extracted values are compared.
+	Type KeyType
+	// Descending reverses the order for this key.
+	Descending bool
+}
+
+// Column returns a key extractor that selects the field at the given
+// offset in a record, returning the empty string if the record has too
+// few fields.
+func Column(i int) func([]string) string {
+	return func(record []string) string {
+		if i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+}
+
+// SortRecords sorts the records by the given keys, in order of
+// precedence, such that records with equal values for the first key
+// are ordered by the second key, and so on. The sort is stable.
+func SortRecords[T any](records []T, keys ...RecordKey[T]) {
+	if len(records) < 2 {
+		return
+	}
+	ApplyPermutation(SortRecordsIndex(records, keys...), records)
+}
+
+// SortRecordsIndex returns the permutation of indices that would sort
+// the records by the given keys, as with SortRecords. The records are
+// not modified.
+func SortRecordsIndex[T any](records []T, keys ...RecordKey[T]) []int {
+	idx := identity(len(records))
+	if len(records) < 2 {
+		return idx
+	}
+	// Extract the key values once, rather than on every comparison.
+	cols := make([]recordColumn, len(keys))
+	for k, key := range keys {
+		col := &cols[k]
+		col.kind = key.Type
+		col.desc = key.Descending
+		col.strs = make([]string, len(records))
+		for i, r := range records {
+			col.strs[i] = key.Extract(r)
+		}
+		if key.Type == NumericKey {
+			col.nums = make([]float64, len(records))
+			col.valid = make([]bool, len(records))
+			for i, s := range col.strs {
+				f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+				col.nums[i] = f
+				col.valid[i] = err == nil && !math.IsNaN(f)
+			}
+		}
+	}
+	multikeyRecordSort(cols, idx, 0)
+	return idx
+}
+
+// recordColumn holds the extracted values of one key for all records.
+type recordColumn struct {
+	kind  KeyType
+	desc  bool
+	strs  []string  // extracted key values
+	nums  []float64 // parsed values for numeric keys
+	valid []bool    // true if the numeric value parsed successfully
+}
+
+// compare compares the key values of records a and b, returning a
+// negative integer, zero, or a positive integer as the value of a is
+// less than, equal to, or greater than that of b.
+func (c *recordColumn) compare(a, b int) int {
+	var r int
+	switch c.kind {
+	case NumericKey:
+		if !c.valid[a] || !c.valid[b] {
+			r = boolCompare(c.valid[a], c.valid[b])
+		} else if c.nums[a] < c.nums[b] {
+			r = -1
+		} else if c.nums[a] > c.nums[b] {
+			r = 1
+		}
+	case NaturalKey:
+		r = naturalCompare(c.strs[a], c.strs[b])
+	default:
+		r = strings.Compare(c.strs[a], c.strs[b])
+	}
+	if c.desc {
+		return -r
+	}
+	return r
+}
+
+// boolCompare orders false before true.
+func boolCompare(a, b bool) int {
+	if a == b {
+		return 0
+	} else if a {
+		return 1
+	}
+	return -1
+}
+
+// multikeyRecordSort sorts the record indices in idx by the keys in
+// cols, starting with the key at offset k. Records that tie on every
+// key are left in order of their original index, making the sort
+// stable. The partitioning follows multikeyPartition, but compares
+// whole key values with recordColumn.compare, where multikeyPartition
+// can only compare the bytes of strings at a given depth.
+func multikeyRecordSort(cols []recordColumn, idx []int, k int) {
+	for len(idx) > 1 {
+		if k == len(cols) {
+			gosort.Ints(idx)
+			return
+		}
+		n := len(idx)
+		if n < insertionThreshold {
+			insertionSortRecords(cols, idx, k)
+			return
+		}
+		col := &cols[k]
+		pm := recordMedian(col, idx, 0, n/2, n-1)
+		if n > 30 {
+			// On larger slices, find a pseudo median of nine elements.
+			d := n / 8
+			pl := recordMedian(col, idx, 0, d, 2*d)
+			pm = recordMedian(col, idx, n/2-d, n/2, n/2+d)
+			pn := recordMedian(col, idx, n-1-2*d, n-1-d, n-1)
+			pm = recordMedian(col, idx, pl, pm, pn)
+		}
+		pivot := idx[pm]
+
+		// Partition into less than, equal to, and greater than pivot.
+		lt, i, gt := 0, 0, n-1
+		for i <= gt {
+			r := col.compare(idx[i], pivot)
+			if r < 0 {
+				idx[lt], idx[i] = idx[i], idx[lt]
+				lt++
+				i++
+			} else if r > 0 {
+				idx[i], idx[gt] = idx[gt], idx[i]
+				gt--
+			} else {
+				i++
+			}
+		}
+		multikeyRecordSort(cols, idx[:lt], k)
+		multikeyRecordSort(cols, idx[gt+1:], k)
+		// Advance to the next key for the records that tied.
+		idx = idx[lt : gt+1]
+		k++
+	}
+}
+
+// recordMedian returns the position of the median of the three records
+// at the given positions, as determined by the key in col.
+func recordMedian(col *recordColumn, idx []int, low, med, high int) int {
+	if col.compare(idx[low], idx[med]) < 0 {
+		if col.compare(idx[med], idx[high]) < 0 {
+			return med
+		} else if col.compare(idx[low], idx[high]) < 0 {
+			return high
+		}
+		return low
+	}
+	if col.compare(idx[med], idx[high]) > 0 {
+		return med
+	} else if col.compare(idx[low], idx[high]) > 0 {
+		return high
+	}
+	return low
+}
+
+// insertionSortRecords sorts the record indices using the keys from
+// offset k onward, breaking ties on the index itself.
+func insertionSortRecords(cols []recordColumn, idx []int, k int) {
+	less := func(a, b int) bool {
+		for j := k; j < len(cols); j++ {
+			if r := cols[j].compare(a, b); r != 0 {
+				return r < 0
+			}
+		}
+		return a < b
+	}
+	for i := 1; i < len(idx); i++ {
+		pivot := idx[i]
+		j := i
+		for j > 0 && less(pivot, idx[j-1]) {
+			idx[j] = idx[j-1]
+			j--
+		}
+		idx[j] = pivot
+	}
+}
+
+// naturalCompare compares two strings such that runs of decimal digits
+// are compared by their numeric value. Strings that are equal in this
+// respect (e.g. "a01" and "a1") are then compared byte-wise.
+func naturalCompare(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ca, cb := a[i], b[j]
+		if isDigit(ca) && isDigit(cb) {
+			// Skip leading zeros, then compare the digit runs first by
+			// length and then digit by digit.
+			si, sj := i, j
+			for si < len(a) && a[si] == '0' {
+				si++
+			}
+			for sj < len(b) && b[sj] == '0' {
+				sj++
+			}
+			ei, ej := si, sj
+			for ei < len(a) && isDigit(a[ei]) {
+				ei++
+			}
+			for ej < len(b) && isDigit(b[ej]) {
+				ej++
+			}
+			if ei-si != ej-sj {
+				return (ei - si) - (ej - sj)
+			}
+			if r := strings.Compare(a[si:ei], b[sj:ej]); r != 0 {
+				return r
+			}
+			i, j = ei, ej
+			continue
+		}
+		if ca != cb {
+			return int(ca) - int(cb)
+		}
+		i++
+		j++
+	}
+	if r := (len(a) - i) - (len(b) - j); r != 0 {
+		return r
+	}
+	return strings.Compare(a, b)
+}
+
+// isDigit returns true if c is a decimal digit.
+func isDigit(c uint8) bool {
+	return c >= '0' && c <= '9'
+}
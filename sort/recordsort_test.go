@@ -0,0 +1,130 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestSortRecordsColumns(t *testing.T) {
+	rows := [][]string{
+		{"b", "x", "10"},
+		{"a", "y", "9"},
+		{"b", "z", "2"},
+		{"a", "y", "10"},
+		{"c", "x", "abc"},
+		{"b", "z", "1.5"},
+	}
+	SortRecords(rows,
+		RecordKey[[]string]{Extract: Column(1)},
+		RecordKey[[]string]{Extract: Column(0), Descending: true},
+		RecordKey[[]string]{Extract: Column(2), Type: NumericKey},
+	)
+	expected := [][]string{
+		{"c", "x", "abc"},
+		{"b", "x", "10"},
+		{"a", "y", "9"},
+		{"a", "y", "10"},
+		{"b", "z", "1.5"},
+		{"b", "z", "2"},
+	}
+	for i, row := range expected {
+		for j, field := range row {
+			if rows[i][j] != field {
+				t.Fatalf("row %d: expected %v, got %v", i, row, rows[i])
+			}
+		}
+	}
+}
+
+func TestSortRecordsStable(t *testing.T) {
+	type record struct {
+		name  string
+		order int
+	}
+	// large enough to exercise the partitioning, not just insertion sort
+	records := make([]record, mediumDataSize)
+	for i := range records {
+		records[i] = record{nonUniqueWords[i][:1], i}
+	}
+	SortRecords(records, RecordKey[record]{
+		Extract: func(r record) string { return r.name },
+	})
+	for i := 1; i < len(records); i++ {
+		a, b := records[i-1], records[i]
+		if a.name > b.name || (a.name == b.name && a.order > b.order) {
+			t.Fatalf("records out of order at %d: %v, %v", i, a, b)
+		}
+	}
+}
+
+func TestSortRecordsMatchesStrings(t *testing.T) {
+	// A single ascending string key must agree with the string sorts.
+	f := func(a []string) {
+		SortRecords(a, RecordKey[string]{Extract: func(s string) string { return s }})
+	}
+	testSortArguments(t, f)
+	testSortRepeatedCycle(t, f, mediumDataSize)
+	testSortRandom(t, f, mediumDataSize)
+	testSortDictWords(t, f, mediumDataSize)
+	testSortNonUnique(t, f, mediumDataSize)
+}
+
+func TestSortRecordsNumeric(t *testing.T) {
+	r := newTestRand(t)
+	values := make([]string, smallDataSize)
+	for i := range values {
+		values[i] = strconv.Itoa(r.Intn(2000) - 1000)
+	}
+	SortRecords(values, RecordKey[string]{
+		Extract:    func(s string) string { return s },
+		Type:       NumericKey,
+		Descending: true,
+	})
+	if !sort.SliceIsSorted(values, func(i, j int) bool {
+		a, _ := strconv.Atoi(values[i])
+		b, _ := strconv.Atoi(values[j])
+		return a > b
+	}) {
+		t.Error("numeric descending input not sorted")
+	}
+}
+
+func TestSortRecordsNumericInvalid(t *testing.T) {
+	// invalid values come first, or last when descending, in their
+	// original order
+	key := RecordKey[string]{Extract: func(s string) string { return s }, Type: NumericKey}
+	values := []string{"3", "x", "-1", "NaN", "10", ""}
+	SortRecords(values, key)
+	if expected := []string{"x", "NaN", "", "-1", "3", "10"}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected %q, got %q", expected, values)
+	}
+	key.Descending = true
+	values = []string{"3", "x", "-1", "NaN", "10", ""}
+	SortRecords(values, key)
+	if expected := []string{"10", "3", "-1", "x", "NaN", ""}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected %q descending, got %q", expected, values)
+	}
+}
+
+func TestNaturalCompare(t *testing.T) {
+	ordered := []string{"", "a", "a01", "a1", "a2", "a10", "a10b", "b", "file9", "file10", "file010x"}
+	for i := 1; i < len(ordered); i++ {
+		if naturalCompare(ordered[i-1], ordered[i]) >= 0 {
+			t.Errorf("expected %q < %q", ordered[i-1], ordered[i])
+		}
+		if naturalCompare(ordered[i], ordered[i-1]) <= 0 {
+			t.Errorf("expected %q > %q", ordered[i], ordered[i-1])
+		}
+	}
+	if naturalCompare("x42", "x42") != 0 {
+		t.Error("expected equal strings to compare equal")
+	}
+}
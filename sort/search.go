@@ -0,0 +1,114 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// Binary search functions for slices of strings that are already in
+// sorted order, such as the output of BurstSort or MultikeyQuickSort.
+// All of these run in O(log n) comparisons.
+
+import (
+	"strings"
+)
+
+// LowerBound returns the index of the first string in sorted that is
+// greater than or equal to s, or len(sorted) if there is none.
+func LowerBound(sorted []string, s string) int {
+	lo, hi := 0, len(sorted)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if sorted[mid] < s {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// UpperBound returns the index of the first string in sorted that is
+// greater than s, or len(sorted) if there is none.
+func UpperBound(sorted []string, s string) int {
+	lo, hi := 0, len(sorted)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if sorted[mid] <= s {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// SearchRange returns the bounds of the strings in sorted that are
+// greater than or equal to from, and less than to, such that
+// sorted[lo:hi] holds exactly those strings.
+func SearchRange(sorted []string, from, to string) (lo, hi int) {
+	lo = LowerBound(sorted, from)
+	if to <= from {
+		return lo, lo
+	}
+	hi = lo + LowerBound(sorted[lo:], to)
+	return
+}
+
+// PrefixRange returns the bounds of the strings in sorted that begin
+// with the given prefix, such that sorted[lo:hi] holds exactly those
+// strings. If there are none, lo and hi are equal.
+func PrefixRange(sorted []string, prefix string) (lo, hi int) {
+	lo = LCPSearch(sorted, prefix)
+	// The strings with the prefix are contiguous, starting at lo.
+	hi = lo
+	n := len(sorted)
+	for hi < n {
+		mid := int(uint(hi+n) >> 1)
+		if strings.HasPrefix(sorted[mid], prefix) {
+			hi = mid + 1
+		} else {
+			n = mid
+		}
+	}
+	return
+}
+
+// LCPSearch is equivalent to LowerBound, but tracks the length of the
+// longest common prefix that s shares with the strings at the bounds of
+// the search. Every string between the bounds shares at least the
+// shorter of those two prefixes with s, so each comparison may skip
+// that many characters. This is most effective when the strings have
+// long shared prefixes, such as URLs or file paths.
+func LCPSearch(sorted []string, s string) int {
+	// Invariant: sorted[lo] < s <= sorted[hi], with the out of range
+	// positions treated as sentinels.
+	lo, hi := -1, len(sorted)
+	llcp, hlcp := 0, 0
+	for hi-lo > 1 {
+		mid := int(uint(lo+hi) >> 1)
+		cmp, lcp := compareFrom(sorted[mid], s, iMin(llcp, hlcp))
+		if cmp < 0 {
+			lo, llcp = mid, lcp
+		} else {
+			hi, hlcp = mid, lcp
+		}
+	}
+	return hi
+}
+
+// compareFrom compares the two strings, which are known to share the
+// first k characters, returning a negative integer, zero, or a positive
+// integer as a is less than, equal to, or greater than b, along with
+// the length of the longest common prefix of the two strings.
+func compareFrom(a, b string, k int) (int, int) {
+	n := iMin(len(a), len(b))
+	for k < n && a[k] == b[k] {
+		k++
+	}
+	if k < n {
+		return int(a[k]) - int(b[k]), k
+	}
+	return len(a) - len(b), k
+}
@@ -0,0 +1,114 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// sortedCopy returns a sorted copy of the first size strings of data.
+func sortedCopy(data []string, size int) []string {
+	sorted := make([]string, size)
+	copy(sorted, data)
+	MultikeyQuickSort(sorted)
+	return sorted
+}
+
+// testSearchBounds compares the bound searches against the standard
+// library for every string in the data, and some that are not.
+func testSearchBounds(t *testing.T, name string, sorted []string) {
+	probes := append([]string{"", "0", "a", "zzzz", "~"}, sorted...)
+	for _, s := range probes {
+		lower := sort.SearchStrings(sorted, s)
+		if i := LowerBound(sorted, s); i != lower {
+			t.Fatalf("%s: LowerBound(%q) = %d, expected %d", name, s, i, lower)
+		}
+		if i := LCPSearch(sorted, s); i != lower {
+			t.Fatalf("%s: LCPSearch(%q) = %d, expected %d", name, s, i, lower)
+		}
+		upper := sort.Search(len(sorted), func(i int) bool { return sorted[i] > s })
+		if i := UpperBound(sorted, s); i != upper {
+			t.Fatalf("%s: UpperBound(%q) = %d, expected %d", name, s, i, upper)
+		}
+		// also search for a string just past this one
+		t2 := s + "\000"
+		if i, j := LCPSearch(sorted, t2), LowerBound(sorted, t2); i != j {
+			t.Fatalf("%s: LCPSearch(%q) = %d, expected %d", name, t2, i, j)
+		}
+	}
+}
+
+// testPrefixRange compares PrefixRange against a linear scan for a
+// variety of prefixes taken from the data.
+func testPrefixRange(t *testing.T, name string, sorted []string) {
+	prefixes := []string{"", "a", "A", "zz", "nosuchprefix"}
+	for i := 0; i < len(sorted); i += len(sorted)/50 + 1 {
+		s := sorted[i]
+		for _, n := range []int{1, 2, 3, len(s)} {
+			if n <= len(s) {
+				prefixes = append(prefixes, s[:n])
+			}
+		}
+	}
+	for _, p := range prefixes {
+		lo, hi := PrefixRange(sorted, p)
+		elo, ehi := len(sorted), len(sorted)
+		for i, s := range sorted {
+			if strings.HasPrefix(s, p) {
+				if elo == len(sorted) {
+					elo = i
+				}
+				ehi = i + 1
+			}
+		}
+		if elo == len(sorted) {
+			// no match, expect an empty range at the insertion point
+			elo = sort.SearchStrings(sorted, p)
+			ehi = elo
+		}
+		if lo != elo || hi != ehi {
+			t.Fatalf("%s: PrefixRange(%q) = [%d,%d), expected [%d,%d)", name, p, lo, hi, elo, ehi)
+		}
+	}
+}
+
+func TestSearchArguments(t *testing.T) {
+	if LowerBound(nil, "a") != 0 || UpperBound(nil, "a") != 0 || LCPSearch(nil, "a") != 0 {
+		t.Error("search of empty input should return zero")
+	}
+	if lo, hi := PrefixRange(nil, "a"); lo != 0 || hi != 0 {
+		t.Error("prefix range of empty input should be empty")
+	}
+	sorted := []string{"", "", "a", "ab", "ab", "abc", "b"}
+	if LowerBound(sorted, "ab") != 3 || UpperBound(sorted, "ab") != 5 {
+		t.Error("bounds of repeated string incorrect")
+	}
+	if lo, hi := PrefixRange(sorted, "ab"); lo != 3 || hi != 6 {
+		t.Errorf("PrefixRange(ab) = [%d,%d), expected [3,6)", lo, hi)
+	}
+	if lo, hi := SearchRange(sorted, "a", "abc"); lo != 2 || hi != 5 {
+		t.Errorf("SearchRange(a, abc) = [%d,%d), expected [2,5)", lo, hi)
+	}
+	if lo, hi := SearchRange(sorted, "b", "a"); lo != hi {
+		t.Error("SearchRange with inverted bounds should be empty")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	sets := map[string][]string{
+		"repeated cycle": sortedCopy(repeatedCycleStrings, mediumDataSize),
+		"random":         sortedCopy(randomStrings, mediumDataSize),
+		"dictwords":      sortedCopy(uniqueWords, mediumDataSize),
+		"non-unique":     sortedCopy(nonUniqueWords, mediumDataSize),
+	}
+	for name, sorted := range sets {
+		testSearchBounds(t, name, sorted)
+		testPrefixRange(t, name, sorted)
+	}
+}
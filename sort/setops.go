@@ -0,0 +1,229 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// Set operations on slices of strings that are already in sorted order.
+// The inputs may contain duplicates, but the results are sets: each
+// distinct string appears only once, in sorted order. All of the
+// operations run in time linear to the combined size of the inputs,
+// except for UnionAll, which takes O(n log k) for k inputs. Strings are
+// compared in full; the prefixes shared by neighboring strings are not
+// used to shorten the comparisons.
+
+import (
+	"container/heap"
+)
+
+// Union returns the strings found in either a or b.
+func Union(a, b []string) []string {
+	result := make([]string, 0, iMax(len(a), len(b)))
+	mergeSets(a, b, func(s string, inA, inB bool) {
+		result = append(result, s)
+	})
+	return result
+}
+
+// Intersect returns the strings found in both a and b.
+func Intersect(a, b []string) []string {
+	result := make([]string, 0, iMin(len(a), len(b)))
+	mergeSets(a, b, func(s string, inA, inB bool) {
+		if inA && inB {
+			result = append(result, s)
+		}
+	})
+	return result
+}
+
+// Difference returns the strings found in a but not in b.
+func Difference(a, b []string) []string {
+	result := make([]string, 0, len(a))
+	mergeSets(a, b, func(s string, inA, inB bool) {
+		if !inB {
+			result = append(result, s)
+		}
+	})
+	return result
+}
+
+// SymmetricDifference returns the strings found in exactly one of a
+// and b.
+func SymmetricDifference(a, b []string) []string {
+	result := make([]string, 0)
+	mergeSets(a, b, func(s string, inA, inB bool) {
+		if inA != inB {
+			result = append(result, s)
+		}
+	})
+	return result
+}
+
+// mergeSets walks the two sorted slices in step, invoking emit once for
+// each distinct string, in sorted order, indicating which of the two
+// slices contain that string.
+func mergeSets(a, b []string, emit func(s string, inA, inB bool)) {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		var s string
+		var inA, inB bool
+		if j == len(b) || (i < len(a) && a[i] < b[j]) {
+			s, inA = a[i], true
+		} else if i == len(a) || b[j] < a[i] {
+			s, inB = b[j], true
+		} else {
+			s, inA, inB = a[i], true, true
+		}
+		emit(s, inA, inB)
+		// skip past any duplicates in either slice
+		for inA && i < len(a) && a[i] == s {
+			i++
+		}
+		for inB && j < len(b) && b[j] == s {
+			j++
+		}
+	}
+}
+
+// UnionAll returns the strings found in any of the given sorted slices,
+// performing a k-way merge of the inputs.
+func UnionAll(sets ...[]string) []string {
+	h := make(mergeHeap, 0, len(sets))
+	total := 0
+	for _, set := range sets {
+		if len(set) > 0 {
+			h = append(h, set)
+			total += len(set)
+		}
+	}
+	heap.Init(&h)
+	result := make([]string, 0, total)
+	for len(h) > 0 {
+		s := h[0][0]
+		if n := len(result); n == 0 || result[n-1] != s {
+			result = append(result, s)
+		}
+		if len(h[0]) > 1 {
+			h[0] = h[0][1:]
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return result
+}
+
+// IntersectAll returns the strings found in every one of the given
+// sorted slices. With no inputs, the result is empty. Rather than
+// intersecting the slices a pair at a time, a cursor in each slice is
+// advanced in turn to the largest string found at any cursor, until
+// all of them agree, so each string of the inputs is visited once.
+func IntersectAll(sets ...[]string) []string {
+	if len(sets) == 0 {
+		return []string{}
+	}
+	shortest := len(sets[0])
+	for _, set := range sets {
+		shortest = iMin(shortest, len(set))
+	}
+	result := make([]string, 0, shortest)
+	if shortest == 0 {
+		return result
+	}
+	pos := make([]int, len(sets))
+	for {
+		target := sets[0][pos[0]]
+		// the number of consecutive slices whose cursor is at target
+		matched := 0
+		for i := 0; matched < len(sets); i = (i + 1) % len(sets) {
+			set := sets[i]
+			p := pos[i]
+			for p < len(set) && set[p] < target {
+				p++
+			}
+			pos[i] = p
+			if p == len(set) {
+				return result
+			}
+			if set[p] == target {
+				matched++
+			} else {
+				target = set[p]
+				matched = 1
+			}
+		}
+		result = append(result, target)
+		// skip past the string, and any duplicates, in every slice
+		for i, set := range sets {
+			for pos[i] < len(set) && set[pos[i]] == target {
+				pos[i]++
+			}
+			if pos[i] == len(set) {
+				return result
+			}
+		}
+	}
+}
+
+// mergeHeap is a min-heap of non-empty sorted slices, ordered by their
+// first elements.
+type mergeHeap [][]string
+
+func (h mergeHeap) Len() int           { return len(h) }
+func (h mergeHeap) Less(i, j int) bool { return h[i][0] < h[j][0] }
+func (h mergeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *mergeHeap) Push(x interface{}) {
+	*h = append(*h, x.([]string))
+}
+
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// MergeJoin walks the two sorted slices in step, in the manner of a
+// sort-merge join, reporting the position of each string. For every
+// pair of equal strings, f is invoked with the index of each within a
+// and b; strings that are found only in a are reported with j = -1,
+// and those found only in b with i = -1. Runs of equal strings produce
+// every pairing of the two runs.
+func MergeJoin(a, b []string, f func(i, j int)) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] < b[j] {
+			f(i, -1)
+			i++
+		} else if b[j] < a[i] {
+			f(-1, j)
+			j++
+		} else {
+			// find the extent of the equal runs in both slices
+			s := a[i]
+			ie, je := i+1, j+1
+			for ie < len(a) && a[ie] == s {
+				ie++
+			}
+			for je < len(b) && b[je] == s {
+				je++
+			}
+			for x := i; x < ie; x++ {
+				for y := j; y < je; y++ {
+					f(x, y)
+				}
+			}
+			i, j = ie, je
+		}
+	}
+	for ; i < len(a); i++ {
+		f(i, -1)
+	}
+	for ; j < len(b); j++ {
+		f(-1, j)
+	}
+}
@@ -0,0 +1,167 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"testing"
+)
+
+// setOf returns the set of distinct strings in the slice.
+func setOf(a []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, s := range a {
+		set[s] = true
+	}
+	return set
+}
+
+// checkSetResult verifies that the result is sorted, free of
+// duplicates, and contains exactly those strings accepted by filter.
+func checkSetResult(t *testing.T, name string, result []string, universe map[string]bool, filter func(string) bool) {
+	for i := 1; i < len(result); i++ {
+		if result[i-1] >= result[i] {
+			t.Fatalf("%s: result not strictly increasing at %d", name, i)
+		}
+	}
+	expected := 0
+	for s := range universe {
+		if filter(s) {
+			expected++
+		}
+	}
+	if len(result) != expected {
+		t.Fatalf("%s: expected %d strings, got %d", name, expected, len(result))
+	}
+	for _, s := range result {
+		if !filter(s) {
+			t.Fatalf("%s: unexpected string %q", name, s)
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	// overlapping, non-unique inputs of differing sizes
+	a := sortedCopy(nonUniqueWords, mediumDataSize)
+	b := sortedCopy(nonUniqueWords[mediumDataSize/2:], mediumDataSize)
+	c := sortedCopy(uniqueWords, smallDataSize)
+	inA, inB, inC := setOf(a), setOf(b), setOf(c)
+	universe := setOf(append(append(append([]string{}, a...), b...), c...))
+
+	checkSetResult(t, "Union", Union(a, b), universe, func(s string) bool {
+		return inA[s] || inB[s]
+	})
+	checkSetResult(t, "Intersect", Intersect(a, b), universe, func(s string) bool {
+		return inA[s] && inB[s]
+	})
+	checkSetResult(t, "Difference", Difference(a, b), universe, func(s string) bool {
+		return inA[s] && !inB[s]
+	})
+	checkSetResult(t, "SymmetricDifference", SymmetricDifference(a, b), universe, func(s string) bool {
+		return inA[s] != inB[s]
+	})
+	checkSetResult(t, "UnionAll", UnionAll(a, nil, b, c), universe, func(s string) bool {
+		return inA[s] || inB[s] || inC[s]
+	})
+	checkSetResult(t, "IntersectAll", IntersectAll(a, b, a), universe, func(s string) bool {
+		return inA[s] && inB[s]
+	})
+	checkSetResult(t, "IntersectAll", IntersectAll(b, c, a), universe, func(s string) bool {
+		return inA[s] && inB[s] && inC[s]
+	})
+	checkSetResult(t, "IntersectAll", IntersectAll(a), universe, func(s string) bool {
+		return inA[s]
+	})
+}
+
+func TestIntersectAll(t *testing.T) {
+	sets := [][]string{
+		{"a", "b", "b", "d", "f", "g", "k"},
+		{"b", "c", "d", "d", "f", "k", "z"},
+		{"a", "b", "d", "e", "f", "k"},
+	}
+	expected := []string{"b", "d", "f", "k"}
+	result := IntersectAll(sets...)
+	if len(result) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("expected %q at %d, got %q", expected[i], i, result[i])
+		}
+	}
+	if r := IntersectAll(sets[0], nil, sets[1]); len(r) != 0 {
+		t.Errorf("intersection with empty input should be empty, got %q", r)
+	}
+}
+
+func TestSetOperationsEmpty(t *testing.T) {
+	a := []string{"a", "a", "b"}
+	if r := Union(nil, nil); len(r) != 0 {
+		t.Error("union of empty inputs should be empty")
+	}
+	if r := Union(a, nil); len(r) != 2 {
+		t.Error("union with empty input should remove duplicates")
+	}
+	if r := Intersect(a, nil); len(r) != 0 {
+		t.Error("intersection with empty input should be empty")
+	}
+	if r := Difference(nil, a); len(r) != 0 {
+		t.Error("difference of empty input should be empty")
+	}
+	if r := UnionAll(); len(r) != 0 {
+		t.Error("union of no inputs should be empty")
+	}
+	if r := IntersectAll(); len(r) != 0 {
+		t.Error("intersection of no inputs should be empty")
+	}
+}
+
+func TestMergeJoin(t *testing.T) {
+	a := []string{"a", "b", "b", "d", "f"}
+	b := []string{"b", "b", "c", "d", "g", "h"}
+	type pair struct{ i, j int }
+	var pairs []pair
+	MergeJoin(a, b, func(i, j int) {
+		pairs = append(pairs, pair{i, j})
+	})
+	expected := []pair{{0, -1}, {1, 0}, {1, 1}, {2, 0}, {2, 1}, {-1, 2},
+		{3, 3}, {4, -1}, {-1, 4}, {-1, 5}}
+	if len(pairs) != len(expected) {
+		t.Fatalf("expected %d pairs, got %d: %v", len(expected), len(pairs), pairs)
+	}
+	for k, p := range expected {
+		if pairs[k] != p {
+			t.Errorf("expected %v at %d, got %v", p, k, pairs[k])
+		}
+	}
+	// every position in each input must be reported at least once
+	x := sortedCopy(nonUniqueWords, mediumDataSize)
+	y := sortedCopy(uniqueWords, mediumDataSize)
+	seenX := make([]bool, len(x))
+	seenY := make([]bool, len(y))
+	MergeJoin(x, y, func(i, j int) {
+		if i >= 0 {
+			seenX[i] = true
+		}
+		if j >= 0 {
+			seenY[j] = true
+		}
+		if i >= 0 && j >= 0 && x[i] != y[j] {
+			t.Fatalf("joined unequal strings %q and %q", x[i], y[j])
+		}
+	})
+	for i, seen := range seenX {
+		if !seen {
+			t.Fatalf("MergeJoin did not report position %d of a", i)
+		}
+	}
+	for j, seen := range seenY {
+		if !seen {
+			t.Fatalf("MergeJoin did not report position %d of b", j)
+		}
+	}
+}
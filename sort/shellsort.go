@@ -35,3 +35,32 @@ func ShellSort(input []string) {
 		}
 	}
 }
+
+// ShellSortFunc sorts the slice using the shell sort, as with
+// ShellSort, ordering the elements by the given less function. The
+// comparisons are made in the same order as by ShellSort, such that
+// for strings, less(x, y) returning x < y yields identical behavior.
+func ShellSortFunc[T any](input []T, less func(x, y T) bool) {
+	size := len(input)
+	if input == nil || size < 2 {
+		return
+	}
+
+	inc := size / 2
+	for inc > 0 {
+		for ii := inc; ii < size; ii++ {
+			temp := input[ii]
+			jj := ii
+			for jj >= inc && less(temp, input[jj-inc]) {
+				input[jj] = input[jj-inc]
+				jj -= inc
+			}
+			input[jj] = temp
+		}
+		if inc == 2 {
+			inc = 1
+		} else {
+			inc = inc * 5 / 11
+		}
+	}
+}
@@ -0,0 +1,253 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// Suffix array construction, using either the SA-IS algorithm by Nong,
+// Zhang, and Chan ("Linear Suffix Array Construction by Almost Pure
+// Induced-Sorting", 2009), or the multikey quicksort applied to the
+// suffixes of the text. The SA-IS implementation follows the structure
+// of the one found in the AtCoder Library.
+
+import (
+	"bytes"
+)
+
+// SuffixArray returns the suffix array of the text: the starting
+// offsets of every suffix of the text, in sorted order. Runs in O(n)
+// time using the SA-IS algorithm.
+func SuffixArray(text []byte) []int {
+	s := make([]int, len(text))
+	for i, c := range text {
+		s[i] = int(c)
+	}
+	return saIs(s, alphabetSize-1)
+}
+
+// SuffixArrayMultikey returns the suffix array of the text, as with
+// SuffixArray, by sorting the suffixes with the multikey quicksort.
+// This serves as a simpler reference implementation, and can be
+// competitive for text without long repeated substrings. As with the
+// string sorts, the null character is treated as a terminator, so the
+// text must not contain null characters.
+func SuffixArrayMultikey(text []byte) []int {
+	n := len(text)
+	str := string(text)
+	suffixes := make([]string, n)
+	for i := range suffixes {
+		suffixes[i] = str[i:]
+	}
+	MultikeyQuickSort(suffixes)
+	// each suffix is identified by its length
+	sa := make([]int, n)
+	for i, s := range suffixes {
+		sa[i] = n - len(s)
+	}
+	return sa
+}
+
+// saIs computes the suffix array of s, whose values are in the range
+// 0 to upper inclusive, using induced sorting.
+func saIs(s []int, upper int) []int {
+	n := len(s)
+	switch n {
+	case 0:
+		return []int{}
+	case 1:
+		return []int{0}
+	case 2:
+		if s[0] < s[1] {
+			return []int{0, 1}
+		}
+		return []int{1, 0}
+	}
+
+	// Classify each suffix as S-type (true) or L-type (false).
+	sa := make([]int, n)
+	ls := make([]bool, n)
+	for i := n - 2; i >= 0; i-- {
+		if s[i] == s[i+1] {
+			ls[i] = ls[i+1]
+		} else {
+			ls[i] = s[i] < s[i+1]
+		}
+	}
+
+	// Compute the start of the L-type and S-type region of each bucket.
+	sumL := make([]int, upper+1)
+	sumS := make([]int, upper+1)
+	for i := 0; i < n; i++ {
+		if !ls[i] {
+			sumS[s[i]]++
+		} else {
+			sumL[s[i]+1]++
+		}
+	}
+	for i := 0; i <= upper; i++ {
+		sumS[i] += sumL[i]
+		if i < upper {
+			sumL[i+1] += sumS[i]
+		}
+	}
+
+	buf := make([]int, upper+1)
+	induce := func(lms []int) {
+		for i := range sa {
+			sa[i] = -1
+		}
+		copy(buf, sumS)
+		for _, d := range lms {
+			if d == n {
+				continue
+			}
+			sa[buf[s[d]]] = d
+			buf[s[d]]++
+		}
+		copy(buf, sumL)
+		sa[buf[s[n-1]]] = n - 1
+		buf[s[n-1]]++
+		for i := 0; i < n; i++ {
+			v := sa[i]
+			if v >= 1 && !ls[v-1] {
+				sa[buf[s[v-1]]] = v - 1
+				buf[s[v-1]]++
+			}
+		}
+		copy(buf, sumL)
+		for i := n - 1; i >= 0; i-- {
+			v := sa[i]
+			if v >= 1 && ls[v-1] {
+				buf[s[v-1]+1]--
+				sa[buf[s[v-1]+1]] = v - 1
+			}
+		}
+	}
+
+	// Find the leftmost S-type (LMS) positions and induce their order.
+	lmsMap := make([]int, n+1)
+	for i := range lmsMap {
+		lmsMap[i] = -1
+	}
+	m := 0
+	for i := 1; i < n; i++ {
+		if !ls[i-1] && ls[i] {
+			lmsMap[i] = m
+			m++
+		}
+	}
+	lms := make([]int, 0, m)
+	for i := 1; i < n; i++ {
+		if !ls[i-1] && ls[i] {
+			lms = append(lms, i)
+		}
+	}
+	induce(lms)
+
+	if m > 0 {
+		// Name the LMS substrings by their sorted order, then sort
+		// the reduced string of names recursively to obtain the final
+		// order of the LMS suffixes.
+		sortedLms := make([]int, 0, m)
+		for _, v := range sa {
+			if lmsMap[v] != -1 {
+				sortedLms = append(sortedLms, v)
+			}
+		}
+		recS := make([]int, m)
+		recUpper := 0
+		recS[lmsMap[sortedLms[0]]] = 0
+		for i := 1; i < m; i++ {
+			l, r := sortedLms[i-1], sortedLms[i]
+			endL, endR := n, n
+			if lmsMap[l]+1 < m {
+				endL = lms[lmsMap[l]+1]
+			}
+			if lmsMap[r]+1 < m {
+				endR = lms[lmsMap[r]+1]
+			}
+			same := true
+			if endL-l != endR-r {
+				same = false
+			} else {
+				for l < endL && s[l] == s[r] {
+					l++
+					r++
+				}
+				if l == n || r == n || s[l] != s[r] {
+					same = false
+				}
+			}
+			if !same {
+				recUpper++
+			}
+			recS[lmsMap[sortedLms[i]]] = recUpper
+		}
+		recSA := saIs(recS, recUpper)
+		for i := 0; i < m; i++ {
+			sortedLms[i] = lms[recSA[i]]
+		}
+		induce(sortedLms)
+	}
+	return sa
+}
+
+// LCPArray computes the longest common prefix array for the text and
+// its suffix array, using the algorithm of Kasai et al. The value at
+// offset i is the length of the longest common prefix of the suffixes
+// at sa[i-1] and sa[i]; the value at offset zero is always zero.
+func LCPArray(text []byte, sa []int) []int {
+	n := len(text)
+	rank := make([]int, n)
+	for i, p := range sa {
+		rank[p] = i
+	}
+	lcp := make([]int, n)
+	h := 0
+	for i := 0; i < n; i++ {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && text[i+h] == text[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
+
+// SuffixSearch finds the occurrences of the pattern within the text,
+// using the suffix array of the text, and returns their starting
+// offsets in suffix order (that is, not sorted by offset). The result
+// is a slice of sa and must not be modified.
+func SuffixSearch(text []byte, sa []int, pattern []byte) []int {
+	// find the first suffix that is not less than the pattern
+	lo, hi := 0, len(sa)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if bytes.Compare(text[sa[mid]:], pattern) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	// the suffixes beginning with the pattern are contiguous
+	start := lo
+	hi = len(sa)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if bytes.HasPrefix(text[sa[mid]:], pattern) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return sa[start:lo]
+}
@@ -0,0 +1,131 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// naiveSuffixArray sorts the suffixes of the text by direct comparison.
+func naiveSuffixArray(text []byte) []int {
+	sa := make([]int, len(text))
+	for i := range sa {
+		sa[i] = i
+	}
+	sort.Slice(sa, func(i, j int) bool {
+		return bytes.Compare(text[sa[i]:], text[sa[j]:]) < 0
+	})
+	return sa
+}
+
+// suffixTestTexts returns a variety of texts, including those with
+// small alphabets and long repetitions that stress the recursion.
+func suffixTestTexts(r *rand.Rand) map[string][]byte {
+	texts := map[string][]byte{
+		"empty":       {},
+		"single":      []byte("a"),
+		"pair":        []byte("ba"),
+		"banana":      []byte("banana"),
+		"mississippi": []byte("mississippi"),
+		"repeated":    []byte(strings.Repeat("a", 300)),
+		"cycle":       []byte(strings.Repeat("abc", 100) + "ab"),
+		"words":       []byte(strings.Join(uniqueWords[:smallDataSize], " ")),
+		"random":      []byte(strings.Join(randomStrings[:20], "")),
+	}
+	genome := make([]byte, 4000)
+	binary := make([]byte, 2000)
+	for i := range genome {
+		genome[i] = "acgt"[r.Intn(4)]
+	}
+	for i := range binary {
+		binary[i] = byte(r.Intn(3))
+	}
+	texts["genome"] = genome
+	texts["binary"] = binary
+	return texts
+}
+
+func TestSuffixArray(t *testing.T) {
+	for name, text := range suffixTestTexts(newTestRand(t)) {
+		expected := naiveSuffixArray(text)
+		actual := SuffixArray(text)
+		if len(actual) != len(expected) {
+			t.Fatalf("%s: expected length %d, got %d", name, len(expected), len(actual))
+		}
+		for i := range expected {
+			if actual[i] != expected[i] {
+				t.Fatalf("%s: SuffixArray differs at %d", name, i)
+			}
+		}
+		if bytes.IndexByte(text, 0) >= 0 {
+			// the multikey version does not support null characters
+			continue
+		}
+		actual = SuffixArrayMultikey(text)
+		for i := range expected {
+			if actual[i] != expected[i] {
+				t.Fatalf("%s: SuffixArrayMultikey differs at %d", name, i)
+			}
+		}
+	}
+}
+
+func TestLCPArray(t *testing.T) {
+	for name, text := range suffixTestTexts(newTestRand(t)) {
+		sa := SuffixArray(text)
+		lcp := LCPArray(text, sa)
+		for i := range sa {
+			expected := 0
+			if i > 0 {
+				a, b := text[sa[i-1]:], text[sa[i]:]
+				for expected < len(a) && expected < len(b) && a[expected] == b[expected] {
+					expected++
+				}
+			}
+			if lcp[i] != expected {
+				t.Fatalf("%s: expected lcp %d at %d, got %d", name, expected, i, lcp[i])
+			}
+		}
+	}
+}
+
+func TestSuffixSearch(t *testing.T) {
+	for name, text := range suffixTestTexts(newTestRand(t)) {
+		sa := SuffixArray(text)
+		patterns := [][]byte{{}, []byte("a"), []byte("ana"), []byte("ssi"), []byte("acgt"), []byte("zzz")}
+		if len(text) > 10 {
+			patterns = append(patterns, text[3:7], text[len(text)-4:])
+		}
+		for _, p := range patterns {
+			found := append([]int(nil), SuffixSearch(text, sa, p)...)
+			sort.Ints(found)
+			var expected []int
+			for i := 0; i+len(p) <= len(text); i++ {
+				if bytes.HasPrefix(text[i:], p) {
+					expected = append(expected, i)
+				}
+			}
+			if len(p) == 0 {
+				// the empty pattern matches every suffix, but not the
+				// empty suffix at the very end
+				expected = expected[:len(text)]
+			}
+			if len(found) != len(expected) {
+				t.Fatalf("%s: search for %q found %d, expected %d", name, p, len(found), len(expected))
+			}
+			for i := range expected {
+				if found[i] != expected[i] {
+					t.Fatalf("%s: search for %q differs at %d", name, p, i)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+// Deduplicating sorts, built on the burst trie and multikey quicksort.
+// Identical strings are collapsed as they are visited during the trie
+// traversal: strings in a null bucket are all equal, and the strings in
+// the equal partition of the multikey quicksort are all equal once the
+// partitioning character is the null terminator. Both hold only if the
+// strings do not contain null characters; if any does, the strings are
+// sorted by a full comparison instead.
+
+// SortUnique sorts the strings and returns the distinct values in
+// sorted order. The sort is performed in place and the result shares
+// the underlying array of the input; the contents of the input beyond
+// the length of the result are unspecified.
+func SortUnique(strings []string) []string {
+	k := 0
+	burstSortRuns(strings, func(s string, n int) {
+		strings[k] = s
+		k++
+	})
+	return strings[:k]
+}
+
+// SortCount sorts the strings and returns the distinct values in sorted
+// order, along with the number of times each value occurred. As with
+// SortUnique, the distinct values share the underlying array of the
+// input.
+func SortCount(strings []string) ([]string, []int) {
+	k := 0
+	var counts []int
+	burstSortRuns(strings, func(s string, n int) {
+		strings[k] = s
+		k++
+		counts = append(counts, n)
+	})
+	return strings[:k], counts
+}
+
+// burstSortRuns sorts the strings using the burstsort algorithm and
+// invokes emit once for each run of identical strings, in sorted order,
+// with the string and the length of the run. The emit function may
+// safely write to the input slice at offsets before the end of the
+// runs visited so far.
+func burstSortRuns(strings []string, emit func(s string, n int)) {
+	if len(strings) == 0 {
+		return
+	}
+	if len(strings) == 1 {
+		emit(strings[0], 1)
+		return
+	}
+	if hasNull(strings) {
+		// strings that differ only after a null character would be
+		// taken as identical by the trie and multikey quicksort
+		IntroSort(strings)
+		emitRuns(strings, emit)
+		return
+	}
+	root := new(burstNode)
+	burstInsert(root, strings)
+	burstTraverseRuns(root, strings, 0, 0, emit)
+}
+
+// burstTraverseRuns is like burstTraverse except that rather than
+// leaving the strings in sorted order, it reports each run of identical
+// strings to the emit function.
+func burstTraverseRuns(node *burstNode, strings []string, pos, depth int, emit func(string, int)) int {
+	for c := 0; c < alphabetSize; c++ {
+		idx := uint8(c)
+		count := node.size(idx)
+		if count < 0 {
+			pos = burstTraverseRuns(node.get(idx).(*burstNode), strings, pos, depth+1, emit)
+		} else if count > 0 {
+			if c == 0 {
+				// The strings in the null buckets are all the same.
+				emit(node.get(idx).(bucket)[0].(string), count)
+			} else {
+				// copy to the input slice to serve as scratch space
+				bucket := node.get(idx).(bucket)
+				dst := strings[pos : pos+count]
+				for i, v := range bucket {
+					dst[i] = v.(string)
+				}
+				multikeyQuickSortRuns(dst, depth+1, emit)
+			}
+			pos += count
+		}
+	}
+	return pos
+}
+
+// multikeyQuickSortRuns sorts the strings as with MultikeyQuickSortDepth
+// and invokes emit for each run of identical strings, in sorted order.
+func multikeyQuickSortRuns(a []string, depth int, emit func(string, int)) {
+	n := len(a)
+	if n < insertionThreshold {
+		insertionSortDepth(a, depth)
+		emitRuns(a, emit)
+		return
+	}
+	lo, hi, _ := multikeyPartition(a, depth)
+	if lo > 0 {
+		multikeyQuickSortRuns(a[:lo], depth, emit)
+	}
+	if charAt(a[lo], depth) == nullterm {
+		// Every string in the equal partition has ended.
+		emitRuns(a[lo:hi], emit)
+	} else {
+		multikeyQuickSortRuns(a[lo:hi], depth+1, emit)
+	}
+	if hi < n {
+		multikeyQuickSortRuns(a[hi:], depth, emit)
+	}
+}
+
+// emitRuns invokes emit for each run of identical strings in the
+// (sorted) slice, reading each run before emitting it.
+func emitRuns(a []string, emit func(string, int)) {
+	for i := 0; i < len(a); {
+		s := a[i]
+		j := i + 1
+		for j < len(a) && a[j] == s {
+			j++
+		}
+		emit(s, j-i)
+		i = j
+	}
+}
@@ -0,0 +1,82 @@
+//
+// Copyright 2026 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package sort
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// checkSortCount runs SortCount and SortUnique over a copy of the given
+// input and compares the results against a simple map-based count.
+func checkSortCount(t *testing.T, name string, data []string) {
+	expected := make(map[string]int)
+	for _, s := range data {
+		expected[s]++
+	}
+	input := make([]string, len(data))
+	copy(input, data)
+	distinct, counts := SortCount(input)
+	if len(distinct) != len(expected) || len(counts) != len(expected) {
+		t.Fatalf("%s: expected %d distinct, got %d (%d counts)", name,
+			len(expected), len(distinct), len(counts))
+	}
+	for i, s := range distinct {
+		if i > 0 && distinct[i-1] >= s {
+			t.Fatalf("%s: distinct values out of order at %d", name, i)
+		}
+		if counts[i] != expected[s] {
+			t.Fatalf("%s: expected count %d for %q, got %d", name, expected[s], s, counts[i])
+		}
+	}
+	copy(input, data)
+	unique := SortUnique(input)
+	if len(unique) != len(expected) {
+		t.Fatalf("%s: expected %d unique, got %d", name, len(expected), len(unique))
+	}
+	if !sort.StringsAreSorted(unique) {
+		t.Fatalf("%s: unique values not sorted", name)
+	}
+}
+
+func TestSortUniqueArguments(t *testing.T) {
+	if len(SortUnique(nil)) != 0 {
+		t.Error("expected empty result for nil input")
+	}
+	distinct, counts := SortCount([]string{"a"})
+	if len(distinct) != 1 || distinct[0] != "a" || counts[0] != 1 {
+		t.Error("single input not counted")
+	}
+	checkSortCount(t, "empty", []string{"", "", "", ""})
+	checkSortCount(t, "peculiar", []string{"z", "m", "", "a", "d", "tt", "tt", "tt", "foo", "bar"})
+}
+
+func TestSortUnique(t *testing.T) {
+	// the repeated cases are the worst-case for burstsort, use small size
+	checkSortCount(t, "repeated", repeatedStrings[:smallDataSize])
+	checkSortCount(t, "repeated cycle", repeatedCycleStrings[:smallDataSize])
+	checkSortCount(t, "random", randomStrings[:mediumDataSize])
+	checkSortCount(t, "dictwords", uniqueWords[:mediumDataSize])
+	checkSortCount(t, "non-unique", nonUniqueWords[:mediumDataSize])
+}
+
+func TestSortUniqueNulls(t *testing.T) {
+	input := []string{"a\x00c", "a", "a\x00b", "a\x00", "a\x00b", "b", "a\x00c"}
+	distinct, counts := SortCount(input)
+	expected := []string{"a", "a\x00", "a\x00b", "a\x00c", "b"}
+	expectedCounts := []int{1, 1, 2, 2, 1}
+	if !reflect.DeepEqual(distinct, expected) || !reflect.DeepEqual(counts, expectedCounts) {
+		t.Errorf("expected %q with counts %v, got %q with %v", expected, expectedCounts, distinct, counts)
+	}
+	// enough strings to burst the trie, some differing only after a null
+	data := make([]string, 0, 2*mediumDataSize)
+	for _, s := range nonUniqueWords[:mediumDataSize] {
+		data = append(data, s, s+"\x00"+s)
+	}
+	checkSortCount(t, "nulls", data)
+}
@@ -10,11 +10,15 @@ package sort
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"math/rand"
+	"os"
 	"sort"
 	"strings"
 	"testing"
+
+	"github.com/nlfiedler/sortingo/internal/generate"
 )
 
 // smallDataSize is the recommended test size for the slower sorting
@@ -31,13 +35,25 @@ const mediumDataSize = 16384
 // allowable size for testing.
 const largeDataSize = 65536
 
+// seedFlag sets the seed for generating the test data, such that the
+// data of a failed run can be generated again, as in
+//
+//	go test -seed 1234 ./sort
+//
+// If zero, the seed is taken from the SORTINGO_SEED environment
+// variable, or else chosen at random.
+var seedFlag = flag.Int64("seed", 0, "seed for generating test data, or zero to choose one")
+
+// testSeed is the seed used to generate the test data.
+var testSeed int64
+
 // repeatedStrings contains a sequence of repeated strings.
 var repeatedStrings []string
 
 // repeatedCycleStrings contains a repeating sequence of strings.
 var repeatedCycleStrings []string
 
-// randomStrings consists of strings of 100 mixed-case letters and numbers.
+// randomStrings consists of strings of 100 printable characters.
 var randomStrings []string
 
 // uniqueWords consists of unique pseudo words, similar to a dictionary.
@@ -46,84 +62,62 @@ var uniqueWords []string
 // nonUniqueWords consists of pseudo words that may repeat numerous times.
 var nonUniqueWords []string
 
-// init sets up the test data one time to avoid regenerating repeatedly.
-func init() {
-	// Generate the repeated strings test data.
-	repeatedStrings = make([]string, largeDataSize)
-	a100 := strings.Repeat("A", 100)
-	for idx := range repeatedStrings {
-		repeatedStrings[idx] = a100
-	}
+// smallAlphaStrings consists of strings of 1 to 100 characters drawn
+// from a small alphabet of nine letters.
+var smallAlphaStrings []string
+
+// genomeStrings consists of strings of 9 characters drawn from the
+// letters a, c, g, t.
+var genomeStrings []string
 
-	// Generate a repeating cycle of strings.
-	strs := make([]string, len(a100))
-	for i := range strs {
-		strs[i] = a100[0 : i+1]
+// TestMain generates the test data one time to avoid regenerating
+// repeatedly, and reports the seed if any of the tests fail.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	seed, err := generate.ChooseSeed(*seedFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
-	repeatedCycleStrings = make([]string, largeDataSize)
-	c := 0
-	for i := range repeatedCycleStrings {
-		repeatedCycleStrings[i] = strs[c]
-		if c++; c >= len(strs) {
-			c = 0
-		}
+	testSeed = seed
+	generateTestData(rand.New(rand.NewSource(testSeed)))
+	code := m.Run()
+	if code != 0 {
+		fmt.Printf("test data generated with -seed %d\n", testSeed)
 	}
+	os.Exit(code)
+}
 
-	// Generate a set of random strings, each of length 100.
-	randomStrings = make([]string, largeDataSize)
-	for i := range randomStrings {
-		bb := bytes.NewBuffer(make([]byte, 0, 100))
-		for j := 0; j < 100; j++ {
-			d := rand.Int31n(62)
-			if d < 10 {
-				bb.WriteRune('0' + d)
-			} else if d < 36 {
-				bb.WriteRune('A' + (d - 10))
-			} else {
-				bb.WriteRune('a' + (d - 36))
-			}
-		}
-		randomStrings[i] = string(bb.Bytes())
-	}
+// newTestRand returns a source for the random choices made by a single
+// test, derived from the test seed and the name of the test, so that
+// the test makes the same choices whether or not it is run alone.
+func newTestRand(t *testing.T) *rand.Rand {
+	return generate.NewRand(testSeed, t.Name())
+}
 
-	// Generate a set of unique pseudo words.
-	uniqueWords = make([]string, largeDataSize)
-	wordExists := make(map[string]bool)
-	for i := range uniqueWords {
-		var s string
-		// Loop until a unique random word is generated.
-		for {
-			// Each word is from 1 to 28 characters long.
-			l := 1 + rand.Intn(27)
-			bb := bytes.NewBuffer(make([]byte, 0, l))
-			// Each word consists only of the lowercase letters.
-			for j := 0; j < l; j++ {
-				d := rand.Int31n(26)
-				bb.WriteRune('a' + d)
-			}
-			s = string(bb.Bytes())
-			if !wordExists[s] {
-				break
-			}
-		}
-		uniqueWords[i] = s
-		wordExists[s] = true
-	}
+// generateTestData creates the data shared by the tests.
+func generateTestData(r *rand.Rand) {
+	repeatedStrings = generate.Repeated(r, largeDataSize)
+	repeatedCycleStrings = generate.RepeatedCycle(r, largeDataSize)
+	randomStrings = generate.Random(r, largeDataSize)
+	uniqueWords = generate.PseudoWords(r, largeDataSize)
+	smallAlphaStrings = generate.SmallAlphabet(r, largeDataSize)
+	genomeStrings = generate.Genome(r, largeDataSize)
 
 	// Generate a set of pseudo words that may be repeated.
 	nonUniqueWords = make([]string, largeDataSize)
 	n := len(nonUniqueWords)
 	for i := 0; i < n; {
 		// Each word is 1 to 28 characters long.
-		l := 1 + rand.Intn(27)
+		l := 1 + r.Intn(27)
 		bb := bytes.NewBuffer(make([]byte, 0, l))
 		// Each word consists only of the lowercase letters.
 		for j := 0; j < l; j++ {
-			d := rand.Int31n(26)
+			d := r.Int31n(26)
 			bb.WriteRune('a' + d)
 		}
 		// Repeat the word some number of times.
-		c := rand.Intn(100)
+		c := r.Intn(100)
 		if c > (n - i) {
 			c = n - i
 		}
@@ -133,7 +127,7 @@ func init() {
 			i++
 		}
 	}
-	shuffle(nonUniqueWords)
+	shuffle(r, nonUniqueWords)
 }
 
 // testSortArguments runs a given sort function with the most
@@ -170,12 +164,9 @@ func testSortArguments(t *testing.T, f func([]string)) {
 // repeated strings.
 func testSortRepeated(t *testing.T, f func([]string), size int) {
 	checkTestSize(t, size)
-	input := make([]string, size)
-	copy(input, repeatedStrings)
-	f(input)
-	if !isRepeated(input) {
-		t.Error("repeated input not repeating")
-	}
+	checkSort(t, f, repeatedStrings[:size], "repeated input not repeating", func(_, out []string) bool {
+		return !isRepeated(out)
+	})
 }
 
 // testSortRepeatedCycle generates a repeating cycle of strings and
@@ -183,36 +174,21 @@ func testSortRepeated(t *testing.T, f func([]string), size int) {
 // to generate for the test.
 func testSortRepeatedCycle(t *testing.T, f func([]string), size int) {
 	checkTestSize(t, size)
-	input := make([]string, size)
-	copy(input, repeatedCycleStrings)
-	f(input)
-	if !sort.StringsAreSorted(input) {
-		t.Error("repeated cycle input not sorted")
-	}
+	checkSort(t, f, repeatedCycleStrings[:size], "repeated cycle input not sorted", notSorted)
 }
 
 // testSortRandom runs the given sort on a randomly generated data set
-// consisting of strings of 100 letters and upper and lower case letters.
+// consisting of strings of 100 printable characters.
 func testSortRandom(t *testing.T, f func([]string), size int) {
 	checkTestSize(t, size)
-	input := make([]string, size)
-	copy(input, randomStrings)
-	f(input)
-	if !sort.StringsAreSorted(input) {
-		t.Error("random input not sorted")
-	}
+	checkSort(t, f, randomStrings[:size], "random input not sorted", notSorted)
 }
 
 // testSortDictWords generates a set of random pseudo-words and runs the
 // given sort function on that set.
 func testSortDictWords(t *testing.T, f func([]string), size int) {
 	checkTestSize(t, size)
-	input := make([]string, size)
-	copy(input, uniqueWords)
-	f(input)
-	if !sort.StringsAreSorted(input) {
-		t.Error("dictwords input not sorted")
-	}
+	checkSort(t, f, uniqueWords[:size], "dictwords input not sorted", notSorted)
 }
 
 // testSortSorted runs the given sort function on an input set that
@@ -222,10 +198,7 @@ func testSortSorted(t *testing.T, f func([]string), size int) {
 	input := make([]string, size)
 	copy(input, uniqueWords)
 	sort.Strings(input)
-	f(input)
-	if !sort.StringsAreSorted(input) {
-		t.Error("sorted dictwords input not sorted")
-	}
+	checkSort(t, f, input, "sorted dictwords input not sorted", notSorted)
 }
 
 // ReverseStringArray is identical to sort.StringArray except that the
@@ -244,10 +217,7 @@ func testSortReversed(t *testing.T, f func([]string), size int) {
 	copy(input, uniqueWords)
 	ri := ReverseStringArray(input)
 	sort.Sort(ri)
-	f(input)
-	if !sort.StringsAreSorted(input) {
-		t.Error("reversed dictwords input not sorted")
-	}
+	checkSort(t, f, input, "reversed dictwords input not sorted", notSorted)
 }
 
 // testSortNonUnique runs the given sort function on a set of words
@@ -255,14 +225,98 @@ func testSortReversed(t *testing.T, f func([]string), size int) {
 // of times).
 func testSortNonUnique(t *testing.T, f func([]string), size int) {
 	checkTestSize(t, size)
-	input := make([]string, size)
-	copy(input, nonUniqueWords)
-	f(input)
-	if !sort.StringsAreSorted(input) {
-		t.Error("non-unique words input not sorted")
+	checkSort(t, f, nonUniqueWords[:size], "non-unique words input not sorted", notSorted)
+}
+
+// notSorted rejects the output of a sort if it is not in order.
+func notSorted(_, output []string) bool {
+	return !sort.StringsAreSorted(output)
+}
+
+// sortFails sorts a copy of the input with f, returning true if the
+// sort panics, or if the rejected function returns true when given the
+// input and the output.
+func sortFails(f func([]string), input []string, rejected func(input, output []string) bool) bool {
+	output := make([]string, len(input))
+	copy(output, input)
+	panicked := func() (panicked bool) {
+		defer func() {
+			if recover() != nil {
+				panicked = true
+			}
+		}()
+		f(output)
+		return false
+	}()
+	return panicked || rejected(input, output)
+}
+
+// checkSort sorts a copy of the input with f, and if the sort panics or
+// its output is rejected, reports the failure along with the seed of
+// the test data and a minimized input on which the sort also fails.
+func checkSort(t *testing.T, f func([]string), input []string, message string, rejected func(input, output []string) bool) {
+	t.Helper()
+	fails := func(input []string) bool {
+		return sortFails(f, input, rejected)
+	}
+	if fails(input) {
+		minimal := minimizeInput(input, fails)
+		t.Errorf("%s (seed %d); minimized failing input: %s", message, testSeed, formatInput(minimal))
 	}
 }
 
+// maxMinimizeRuns limits the number of times that minimizeInput calls
+// the failure function.
+const maxMinimizeRuns = 2000
+
+// minimizeInput reduces a failing input to a smaller one that still
+// fails, in the manner of delta debugging: chunks of the input are
+// removed, halving the size of the chunks after each pass, and then
+// single elements are removed until none can be. The order of the
+// remaining elements is retained.
+func minimizeInput(input []string, fails func([]string) bool) []string {
+	runs := 0
+	for chunk := max(1, len(input)/2); runs < maxMinimizeRuns; {
+		removed := false
+		for start := 0; start < len(input) && runs < maxMinimizeRuns; {
+			end := min(start+chunk, len(input))
+			candidate := append(input[:start:start], input[end:]...)
+			runs++
+			if fails(candidate) {
+				input = candidate
+				removed = true
+			} else {
+				start = end
+			}
+		}
+		if chunk > 1 {
+			chunk /= 2
+		} else if !removed {
+			break
+		}
+	}
+	return input
+}
+
+// formatInput describes the input for a failure message, abbreviating
+// long inputs and strings.
+func formatInput(input []string) string {
+	const maxStrings, maxLen = 20, 40
+	quoted := make([]string, 0, maxStrings+1)
+	for i, s := range input {
+		if i == maxStrings {
+			quoted = append(quoted, fmt.Sprintf("... (%d more)", len(input)-maxStrings))
+			break
+		}
+		if len(s) > maxLen {
+			quoted = append(quoted, fmt.Sprintf("%q...", s[:maxLen]))
+		} else {
+			quoted = append(quoted, fmt.Sprintf("%q", s))
+		}
+	}
+	return fmt.Sprintf("%d strings [%s]", len(input), strings.Join(quoted, " "))
+}
+
 // checkTestSize compares the given size argument to the maximum
 // allowable value, logging an error and failing the test if the
 // value is too large.
@@ -273,9 +327,9 @@ func checkTestSize(t *testing.T, size int) {
 }
 
 // shuffle randomly shuffles the elements in the string slice.
-func shuffle(input []string) {
+func shuffle(r *rand.Rand, input []string) {
 	n := len(input)
-	indices := rand.Perm(n)
+	indices := r.Perm(n)
 	for i := 0; i < n; i++ {
 		j := indices[i]
 		input[i], input[j] = input[j], input[i]
@@ -284,12 +338,30 @@ func shuffle(input []string) {
 
 // isRepeated tests if the array consists only of repeated strings.
 func isRepeated(arr []string) bool {
-	s := arr[0]
-	for i, a := range arr {
-		if a != s {
-			fmt.Printf("%s != %s @ %d\n", a, s, i)
+	for _, a := range arr {
+		if a != arr[0] {
 			return false
 		}
 	}
 	return true
 }
+
+func TestMinimizeInput(t *testing.T) {
+	// fails if "b" precedes "a" anywhere in the input
+	fails := func(input []string) bool {
+		seenB := false
+		for _, s := range input {
+			if s == "b" {
+				seenB = true
+			} else if s == "a" && seenB {
+				return true
+			}
+		}
+		return false
+	}
+	input := strings.Fields("x y b z z a w b q r s a t")
+	minimal := minimizeInput(input, fails)
+	if len(minimal) != 2 || minimal[0] != "b" || minimal[1] != "a" {
+		t.Errorf("expected [b a], got %q", minimal)
+	}
+}